@@ -15,6 +15,96 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/breeds": {
+            "get": {
+                "description": "Returns the breed catalog synced from the dog API with sub-breeds, ordered by name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Breeds"
+                ],
+                "summary": "List breeds",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_internal_models_breeds.BreedsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/dogs": {
+            "get": {
+                "description": "Returns archived dog images ordered from newest to oldest with cursor pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dogs"
+                ],
+                "summary": "List archived dogs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by breed",
+                        "name": "breed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created at or after (RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created before (RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_internal_models_dogs.DogsPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/dogs/{breed}/image": {
             "get": {
                 "description": "Retrieves a random dog image for the specified breed, downloads it, and uploads to S3",
@@ -24,7 +114,365 @@ const docTemplate = `{
                 "tags": [
                     "Dogs"
                 ],
-                "summary": "Get random dog image by breed",
+                "summary": "Get random dog image by breed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dog breed",
+                        "name": "breed",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "S3 URL of the uploaded image",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_internal_models_dogs.DogImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/dogs/{breed}/image:async": {
+            "post": {
+                "description": "Queues fetching a random dog image for the breed, uploading it to S3 and saving it. Poll the job for the result",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dogs"
+                ],
+                "summary": "Queue dog image ingestion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dog breed",
+                        "name": "breed",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_internal_models_jobs.JobAcceptedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/dogs/{breed}/images": {
+            "post": {
+                "description": "Retrieves count random images for the breed, downloads and uploads them to S3 in parallel and saves them. Failed images are reported per item and do not fail the request",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dogs"
+                ],
+                "summary": "Ingest several random dog images by breed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dog breed",
+                        "name": "breed",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of images",
+                        "name": "count",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_internal_models_dogs.BatchImagesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/dogs/{breed}/uploads": {
+            "post": {
+                "description": "Issues a presigned PUT request so the client can upload its own dog photo directly to S3. The returned headers must be sent with the upload",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dogs"
+                ],
+                "summary": "Get a presigned upload URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dog breed",
+                        "name": "breed",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "image/jpeg",
+                        "description": "Image content type",
+                        "name": "content_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_internal_models_dogs.UploadURLResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/dogs/{breed}/{subBreed}/image": {
+            "get": {
+                "description": "Retrieves a random dog image for the specified sub-breed, downloads it, and uploads to S3",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dogs"
+                ],
+                "summary": "Get random dog image by sub-breed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dog breed",
+                        "name": "breed",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dog sub-breed",
+                        "name": "subBreed",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "S3 URL of the uploaded image",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_internal_models_dogs.DogImageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/dogs/{breed}/{subBreed}/image:async": {
+            "post": {
+                "description": "Queues fetching a random dog image for the sub-breed, uploading it to S3 and saving it. Poll the job for the result",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dogs"
+                ],
+                "summary": "Queue dog image ingestion by sub-breed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dog breed",
+                        "name": "breed",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dog sub-breed",
+                        "name": "subBreed",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_internal_models_jobs.JobAcceptedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/dogs/{breed}/{subBreed}/images": {
+            "post": {
+                "description": "Retrieves count random images for the sub-breed, downloads and uploads them to S3 in parallel and saves them. Failed images are reported per item and do not fail the request",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dogs"
+                ],
+                "summary": "Ingest several random dog images by sub-breed",
                 "parameters": [
                     {
                         "type": "string",
@@ -32,13 +480,27 @@ const docTemplate = `{
                         "name": "breed",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dog sub-breed",
+                        "name": "subBreed",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of images",
+                        "name": "count",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "S3 URL of the uploaded image",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/go-platform_internal_models_dogs.DogImageResponse"
+                            "$ref": "#/definitions/go-platform_internal_models_dogs.BatchImagesResponse"
                         }
                     },
                     "400": {
@@ -53,6 +515,100 @@ const docTemplate = `{
                             "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
                         }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/dogs/{id}": {
+            "get": {
+                "description": "Returns a previously archived dog image from the storage",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Dogs"
+                ],
+                "summary": "Get archived dog by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dog ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_internal_models_dogs.Dog"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/jobs/{id}": {
+            "get": {
+                "description": "Returns the status of an asynchronous ingestion job and the S3 URL once it succeeded",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Get job status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_internal_models_jobs.Job"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/go-platform_pkg_utils_http-utils.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -92,17 +648,270 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "go-platform_internal_models_breeds.Breed": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "sub_breeds": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "go-platform_internal_models_breeds.BreedsResponse": {
+            "type": "object",
+            "properties": {
+                "breeds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-platform_internal_models_breeds.Breed"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-platform_internal_models_dogs.BatchImageResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "medium_url": {
+                    "type": "string"
+                },
+                "source_url": {
+                    "type": "string"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-platform_internal_models_dogs.BatchImagesResponse": {
+            "type": "object",
+            "properties": {
+                "breed": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "requested": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-platform_internal_models_dogs.BatchImageResult"
+                    }
+                },
+                "sub_breed": {
+                    "type": "string"
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-platform_internal_models_dogs.Dog": {
+            "type": "object",
+            "properties": {
+                "breed": {
+                    "type": "string"
+                },
+                "content_hash": {
+                    "description": "ContentHash is the hex SHA-256 of the image bytes, identical images share it",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_key": {
+                    "description": "ImageKey is the S3 object key, ImageURL is presigned from it when the dog is served.\nRows archived before keys were stored only have ImageURL.",
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "medium_key": {
+                    "type": "string"
+                },
+                "medium_url": {
+                    "type": "string"
+                },
+                "mime_type": {
+                    "type": "string"
+                },
+                "sub_breed": {
+                    "description": "SubBreed is set for dog.ceo style sub-breeds like hound/afghan",
+                    "type": "string"
+                },
+                "thumbnail_key": {
+                    "description": "Renditions are JPEGs stored next to the original, their URLs are presigned like ImageURL",
+                    "type": "string"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
         "go-platform_internal_models_dogs.DogImageResponse": {
             "type": "object",
             "properties": {
                 "breed": {
                     "type": "string"
                 },
+                "height": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "medium_url": {
+                    "type": "string"
+                },
+                "mime_type": {
+                    "type": "string"
+                },
+                "sub_breed": {
+                    "type": "string"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
+                }
+            }
+        },
+        "go-platform_internal_models_dogs.DogsPage": {
+            "type": "object",
+            "properties": {
+                "dogs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/go-platform_internal_models_dogs.Dog"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-platform_internal_models_dogs.UploadURLResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "key": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-platform_internal_models_jobs.Job": {
+            "type": "object",
+            "properties": {
+                "breed": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dog_id": {
+                    "description": "DogID is the archived dog of a succeeded job, ImageURL is presigned from it when the job is read",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
                 "image_url": {
                     "type": "string"
+                },
+                "instance": {
+                    "description": "Instance is the process that queued the job, the queue lives in its memory",
+                    "type": "string"
+                },
+                "request_id": {
+                    "description": "RequestID is the ID of the request that queued the job, its worker logs with it",
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/go-platform_internal_models_jobs.Status"
+                },
+                "sub_breed": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "go-platform_internal_models_jobs.JobAcceptedResponse": {
+            "type": "object",
+            "properties": {
+                "job_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/go-platform_internal_models_jobs.Status"
+                },
+                "status_url": {
+                    "type": "string"
                 }
             }
         },
+        "go-platform_internal_models_jobs.Status": {
+            "type": "string",
+            "enum": [
+                "queued",
+                "running",
+                "succeeded",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "StatusQueued",
+                "StatusRunning",
+                "StatusSucceeded",
+                "StatusFailed"
+            ]
+        },
         "go-platform_pkg_utils_http-utils.ErrorDetail": {
             "type": "object",
             "properties": {
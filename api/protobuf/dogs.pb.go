@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.35.2
+// 	protoc-gen-go v1.36.8
 // 	protoc        v5.29.0
 // source: api/protobuf/dogs.proto
 
@@ -12,6 +12,7 @@ import (
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
+	unsafe "unsafe"
 )
 
 const (
@@ -23,11 +24,12 @@ const (
 
 // Request message for getting a random dog image by breed
 type GetRandomDogImageRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Breed string                 `protobuf:"bytes,1,opt,name=breed,proto3" json:"breed,omitempty"`
+	// optional, e.g. afghan for the hound breed
+	SubBreed      string `protobuf:"bytes,2,opt,name=sub_breed,json=subBreed,proto3" json:"sub_breed,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	Breed string `protobuf:"bytes,1,opt,name=breed,proto3" json:"breed,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetRandomDogImageRequest) Reset() {
@@ -67,15 +69,27 @@ func (x *GetRandomDogImageRequest) GetBreed() string {
 	return ""
 }
 
+func (x *GetRandomDogImageRequest) GetSubBreed() string {
+	if x != nil {
+		return x.SubBreed
+	}
+	return ""
+}
+
 // Response message containing the dog image information
 type GetRandomDogImageResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImageUrl      string                 `protobuf:"bytes,1,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Breed         string                 `protobuf:"bytes,2,opt,name=breed,proto3" json:"breed,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ThumbnailUrl  string                 `protobuf:"bytes,5,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	MediumUrl     string                 `protobuf:"bytes,6,opt,name=medium_url,json=mediumUrl,proto3" json:"medium_url,omitempty"`
+	Width         int32                  `protobuf:"varint,7,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,8,opt,name=height,proto3" json:"height,omitempty"`
+	MimeType      string                 `protobuf:"bytes,9,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	SubBreed      string                 `protobuf:"bytes,10,opt,name=sub_breed,json=subBreed,proto3" json:"sub_breed,omitempty"`
 	unknownFields protoimpl.UnknownFields
-
-	ImageUrl  string                 `protobuf:"bytes,1,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
-	Breed     string                 `protobuf:"bytes,2,opt,name=breed,proto3" json:"breed,omitempty"`
-	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetRandomDogImageResponse) Reset() {
@@ -129,20 +143,637 @@ func (x *GetRandomDogImageResponse) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-// Error response message
-type ErrorResponse struct {
-	state         protoimpl.MessageState
+func (x *GetRandomDogImageResponse) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *GetRandomDogImageResponse) GetMediumUrl() string {
+	if x != nil {
+		return x.MediumUrl
+	}
+	return ""
+}
+
+func (x *GetRandomDogImageResponse) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *GetRandomDogImageResponse) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetRandomDogImageResponse) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *GetRandomDogImageResponse) GetSubBreed() string {
+	if x != nil {
+		return x.SubBreed
+	}
+	return ""
+}
+
+// Archived dog image stored in the database
+type Dog struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,2,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Breed         string                 `protobuf:"bytes,3,opt,name=breed,proto3" json:"breed,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ThumbnailUrl  string                 `protobuf:"bytes,5,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	MediumUrl     string                 `protobuf:"bytes,6,opt,name=medium_url,json=mediumUrl,proto3" json:"medium_url,omitempty"`
+	Width         int32                  `protobuf:"varint,7,opt,name=width,proto3" json:"width,omitempty"`
+	Height        int32                  `protobuf:"varint,8,opt,name=height,proto3" json:"height,omitempty"`
+	MimeType      string                 `protobuf:"bytes,9,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	SubBreed      string                 `protobuf:"bytes,10,opt,name=sub_breed,json=subBreed,proto3" json:"sub_breed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dog) Reset() {
+	*x = Dog{}
+	mi := &file_api_protobuf_dogs_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dog) ProtoMessage() {}
+
+func (x *Dog) ProtoReflect() protoreflect.Message {
+	mi := &file_api_protobuf_dogs_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dog.ProtoReflect.Descriptor instead.
+func (*Dog) Descriptor() ([]byte, []int) {
+	return file_api_protobuf_dogs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Dog) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Dog) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *Dog) GetBreed() string {
+	if x != nil {
+		return x.Breed
+	}
+	return ""
+}
+
+func (x *Dog) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Dog) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *Dog) GetMediumUrl() string {
+	if x != nil {
+		return x.MediumUrl
+	}
+	return ""
+}
+
+func (x *Dog) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Dog) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Dog) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *Dog) GetSubBreed() string {
+	if x != nil {
+		return x.SubBreed
+	}
+	return ""
+}
+
+// Request message for getting an archived dog by ID
+type GetDogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDogRequest) Reset() {
+	*x = GetDogRequest{}
+	mi := &file_api_protobuf_dogs_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDogRequest) ProtoMessage() {}
+
+func (x *GetDogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_protobuf_dogs_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDogRequest.ProtoReflect.Descriptor instead.
+func (*GetDogRequest) Descriptor() ([]byte, []int) {
+	return file_api_protobuf_dogs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetDogRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Request message for listing archived dogs, newest first.
+// from is inclusive, to is exclusive; unset fields disable the filter
+type ListDogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Breed         string                 `protobuf:"bytes,1,opt,name=breed,proto3" json:"breed,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDogsRequest) Reset() {
+	*x = ListDogsRequest{}
+	mi := &file_api_protobuf_dogs_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDogsRequest) ProtoMessage() {}
+
+func (x *ListDogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_protobuf_dogs_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDogsRequest.ProtoReflect.Descriptor instead.
+func (*ListDogsRequest) Descriptor() ([]byte, []int) {
+	return file_api_protobuf_dogs_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListDogsRequest) GetBreed() string {
+	if x != nil {
+		return x.Breed
+	}
+	return ""
+}
+
+func (x *ListDogsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListDogsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListDogsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListDogsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Response message containing a page of archived dogs
+type ListDogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dogs          []*Dog                 `protobuf:"bytes,1,rep,name=dogs,proto3" json:"dogs,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDogsResponse) Reset() {
+	*x = ListDogsResponse{}
+	mi := &file_api_protobuf_dogs_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDogsResponse) ProtoMessage() {}
+
+func (x *ListDogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_protobuf_dogs_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDogsResponse.ProtoReflect.Descriptor instead.
+func (*ListDogsResponse) Descriptor() ([]byte, []int) {
+	return file_api_protobuf_dogs_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListDogsResponse) GetDogs() []*Dog {
+	if x != nil {
+		return x.Dogs
+	}
+	return nil
+}
+
+func (x *ListDogsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// Request message for ingesting several random images of a breed
+type GetRandomDogImagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Breed string                 `protobuf:"bytes,1,opt,name=breed,proto3" json:"breed,omitempty"`
+	Count int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// optional, e.g. afghan for the hound breed
+	SubBreed      string `protobuf:"bytes,3,opt,name=sub_breed,json=subBreed,proto3" json:"sub_breed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRandomDogImagesRequest) Reset() {
+	*x = GetRandomDogImagesRequest{}
+	mi := &file_api_protobuf_dogs_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRandomDogImagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRandomDogImagesRequest) ProtoMessage() {}
+
+func (x *GetRandomDogImagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_protobuf_dogs_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRandomDogImagesRequest.ProtoReflect.Descriptor instead.
+func (*GetRandomDogImagesRequest) Descriptor() ([]byte, []int) {
+	return file_api_protobuf_dogs_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetRandomDogImagesRequest) GetBreed() string {
+	if x != nil {
+		return x.Breed
+	}
+	return ""
+}
+
+func (x *GetRandomDogImagesRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetRandomDogImagesRequest) GetSubBreed() string {
+	if x != nil {
+		return x.SubBreed
+	}
+	return ""
+}
+
+// Outcome of a single image of a batch, streamed as soon as it completes.
+// error is set and image_url is empty when the image failed
+type DogImageResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	SourceUrl     string                 `protobuf:"bytes,2,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,3,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	ThumbnailUrl  string                 `protobuf:"bytes,5,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	MediumUrl     string                 `protobuf:"bytes,6,opt,name=medium_url,json=mediumUrl,proto3" json:"medium_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DogImageResult) Reset() {
+	*x = DogImageResult{}
+	mi := &file_api_protobuf_dogs_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DogImageResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DogImageResult) ProtoMessage() {}
+
+func (x *DogImageResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_protobuf_dogs_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DogImageResult.ProtoReflect.Descriptor instead.
+func (*DogImageResult) Descriptor() ([]byte, []int) {
+	return file_api_protobuf_dogs_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DogImageResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *DogImageResult) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *DogImageResult) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *DogImageResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DogImageResult) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *DogImageResult) GetMediumUrl() string {
+	if x != nil {
+		return x.MediumUrl
+	}
+	return ""
+}
+
+// Request message for listing the breed catalog
+type ListBreedsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBreedsRequest) Reset() {
+	*x = ListBreedsRequest{}
+	mi := &file_api_protobuf_dogs_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBreedsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBreedsRequest) ProtoMessage() {}
+
+func (x *ListBreedsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_protobuf_dogs_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBreedsRequest.ProtoReflect.Descriptor instead.
+func (*ListBreedsRequest) Descriptor() ([]byte, []int) {
+	return file_api_protobuf_dogs_proto_rawDescGZIP(), []int{8}
+}
+
+// Breed of the catalog with its sub-breeds
+type Breed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SubBreeds     []string               `protobuf:"bytes,2,rep,name=sub_breeds,json=subBreeds,proto3" json:"sub_breeds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Breed) Reset() {
+	*x = Breed{}
+	mi := &file_api_protobuf_dogs_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Breed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Breed) ProtoMessage() {}
+
+func (x *Breed) ProtoReflect() protoreflect.Message {
+	mi := &file_api_protobuf_dogs_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Breed.ProtoReflect.Descriptor instead.
+func (*Breed) Descriptor() ([]byte, []int) {
+	return file_api_protobuf_dogs_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Breed) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Breed) GetSubBreeds() []string {
+	if x != nil {
+		return x.SubBreeds
+	}
+	return nil
+}
+
+// Response message containing the breed catalog ordered by name
+type ListBreedsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Breeds        []*Breed               `protobuf:"bytes,1,rep,name=breeds,proto3" json:"breeds,omitempty"`
 	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-	Message    string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Error      string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	StatusCode int32  `protobuf:"varint,3,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+func (x *ListBreedsResponse) Reset() {
+	*x = ListBreedsResponse{}
+	mi := &file_api_protobuf_dogs_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBreedsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBreedsResponse) ProtoMessage() {}
+
+func (x *ListBreedsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_protobuf_dogs_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBreedsResponse.ProtoReflect.Descriptor instead.
+func (*ListBreedsResponse) Descriptor() ([]byte, []int) {
+	return file_api_protobuf_dogs_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListBreedsResponse) GetBreeds() []*Breed {
+	if x != nil {
+		return x.Breeds
+	}
+	return nil
+}
+
+// Error response message
+type ErrorResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	StatusCode    int32                  `protobuf:"varint,3,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ErrorResponse) Reset() {
 	*x = ErrorResponse{}
-	mi := &file_api_protobuf_dogs_proto_msgTypes[2]
+	mi := &file_api_protobuf_dogs_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -154,7 +785,7 @@ func (x *ErrorResponse) String() string {
 func (*ErrorResponse) ProtoMessage() {}
 
 func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_protobuf_dogs_proto_msgTypes[2]
+	mi := &file_api_protobuf_dogs_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -167,7 +798,7 @@ func (x *ErrorResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ErrorResponse.ProtoReflect.Descriptor instead.
 func (*ErrorResponse) Descriptor() ([]byte, []int) {
-	return file_api_protobuf_dogs_proto_rawDescGZIP(), []int{2}
+	return file_api_protobuf_dogs_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ErrorResponse) GetMessage() string {
@@ -193,69 +824,135 @@ func (x *ErrorResponse) GetStatusCode() int32 {
 
 var File_api_protobuf_dogs_proto protoreflect.FileDescriptor
 
-var file_api_protobuf_dogs_proto_rawDesc = []byte{
-	0x0a, 0x17, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64,
-	0x6f, 0x67, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x67, 0x6f, 0x5f, 0x70, 0x6c,
-	0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x64, 0x6f, 0x67, 0x73, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x30, 0x0a, 0x18,
-	0x47, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x44, 0x6f, 0x67, 0x49, 0x6d, 0x61, 0x67,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x72, 0x65, 0x65,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x72, 0x65, 0x65, 0x64, 0x22, 0x89,
-	0x01, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x44, 0x6f, 0x67, 0x49,
-	0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09,
-	0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x55, 0x72, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x72, 0x65,
-	0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x72, 0x65, 0x65, 0x64, 0x12,
-	0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x60, 0x0a, 0x0d, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x32, 0x7a, 0x0a, 0x0a,
-	0x44, 0x6f, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6c, 0x0a, 0x11, 0x47, 0x65,
-	0x74, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x44, 0x6f, 0x67, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x12,
-	0x2a, 0x2e, 0x67, 0x6f, 0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x64, 0x6f,
-	0x67, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x44, 0x6f, 0x67, 0x49,
-	0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x67, 0x6f,
-	0x5f, 0x70, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2e, 0x64, 0x6f, 0x67, 0x73, 0x2e, 0x47,
-	0x65, 0x74, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x44, 0x6f, 0x67, 0x49, 0x6d, 0x61, 0x67, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0d, 0x5a, 0x0b, 0x64, 0x6f, 0x67, 0x73,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
+const file_api_protobuf_dogs_proto_rawDesc = "" +
+	"\n" +
+	"\x17api/protobuf/dogs.proto\x12\x10go_platform.dogs\x1a\x1fgoogle/protobuf/timestamp.proto\"M\n" +
+	"\x18GetRandomDogImageRequest\x12\x14\n" +
+	"\x05breed\x18\x01 \x01(\tR\x05breed\x12\x1b\n" +
+	"\tsub_breed\x18\x02 \x01(\tR\bsubBreed\"\xb5\x02\n" +
+	"\x19GetRandomDogImageResponse\x12\x1b\n" +
+	"\timage_url\x18\x01 \x01(\tR\bimageUrl\x12\x14\n" +
+	"\x05breed\x18\x02 \x01(\tR\x05breed\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12#\n" +
+	"\rthumbnail_url\x18\x05 \x01(\tR\fthumbnailUrl\x12\x1d\n" +
+	"\n" +
+	"medium_url\x18\x06 \x01(\tR\tmediumUrl\x12\x14\n" +
+	"\x05width\x18\a \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\b \x01(\x05R\x06height\x12\x1b\n" +
+	"\tmime_type\x18\t \x01(\tR\bmimeType\x12\x1b\n" +
+	"\tsub_breed\x18\n" +
+	" \x01(\tR\bsubBreed\"\xaf\x02\n" +
+	"\x03Dog\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\timage_url\x18\x02 \x01(\tR\bimageUrl\x12\x14\n" +
+	"\x05breed\x18\x03 \x01(\tR\x05breed\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12#\n" +
+	"\rthumbnail_url\x18\x05 \x01(\tR\fthumbnailUrl\x12\x1d\n" +
+	"\n" +
+	"medium_url\x18\x06 \x01(\tR\tmediumUrl\x12\x14\n" +
+	"\x05width\x18\a \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\b \x01(\x05R\x06height\x12\x1b\n" +
+	"\tmime_type\x18\t \x01(\tR\bmimeType\x12\x1b\n" +
+	"\tsub_breed\x18\n" +
+	" \x01(\tR\bsubBreed\"\x1f\n" +
+	"\rGetDogRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xb1\x01\n" +
+	"\x0fListDogsRequest\x12\x14\n" +
+	"\x05breed\x18\x01 \x01(\tR\x05breed\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"^\n" +
+	"\x10ListDogsResponse\x12)\n" +
+	"\x04dogs\x18\x01 \x03(\v2\x15.go_platform.dogs.DogR\x04dogs\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"d\n" +
+	"\x19GetRandomDogImagesRequest\x12\x14\n" +
+	"\x05breed\x18\x01 \x01(\tR\x05breed\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1b\n" +
+	"\tsub_breed\x18\x03 \x01(\tR\bsubBreed\"\xbc\x01\n" +
+	"\x0eDogImageResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\x02 \x01(\tR\tsourceUrl\x12\x1b\n" +
+	"\timage_url\x18\x03 \x01(\tR\bimageUrl\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12#\n" +
+	"\rthumbnail_url\x18\x05 \x01(\tR\fthumbnailUrl\x12\x1d\n" +
+	"\n" +
+	"medium_url\x18\x06 \x01(\tR\tmediumUrl\"\x13\n" +
+	"\x11ListBreedsRequest\":\n" +
+	"\x05Breed\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"sub_breeds\x18\x02 \x03(\tR\tsubBreeds\"E\n" +
+	"\x12ListBreedsResponse\x12/\n" +
+	"\x06breeds\x18\x01 \x03(\v2\x17.go_platform.dogs.BreedR\x06breeds\"`\n" +
+	"\rErrorResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1f\n" +
+	"\vstatus_code\x18\x03 \x01(\x05R\n" +
+	"statusCode2\xcf\x03\n" +
+	"\n" +
+	"DogService\x12l\n" +
+	"\x11GetRandomDogImage\x12*.go_platform.dogs.GetRandomDogImageRequest\x1a+.go_platform.dogs.GetRandomDogImageResponse\x12@\n" +
+	"\x06GetDog\x12\x1f.go_platform.dogs.GetDogRequest\x1a\x15.go_platform.dogs.Dog\x12Q\n" +
+	"\bListDogs\x12!.go_platform.dogs.ListDogsRequest\x1a\".go_platform.dogs.ListDogsResponse\x12e\n" +
+	"\x12GetRandomDogImages\x12+.go_platform.dogs.GetRandomDogImagesRequest\x1a .go_platform.dogs.DogImageResult0\x01\x12W\n" +
+	"\n" +
+	"ListBreeds\x12#.go_platform.dogs.ListBreedsRequest\x1a$.go_platform.dogs.ListBreedsResponseB\rZ\vdogs/proto/b\x06proto3"
 
 var (
 	file_api_protobuf_dogs_proto_rawDescOnce sync.Once
-	file_api_protobuf_dogs_proto_rawDescData = file_api_protobuf_dogs_proto_rawDesc
+	file_api_protobuf_dogs_proto_rawDescData []byte
 )
 
 func file_api_protobuf_dogs_proto_rawDescGZIP() []byte {
 	file_api_protobuf_dogs_proto_rawDescOnce.Do(func() {
-		file_api_protobuf_dogs_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_protobuf_dogs_proto_rawDescData)
+		file_api_protobuf_dogs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_protobuf_dogs_proto_rawDesc), len(file_api_protobuf_dogs_proto_rawDesc)))
 	})
 	return file_api_protobuf_dogs_proto_rawDescData
 }
 
-var file_api_protobuf_dogs_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_api_protobuf_dogs_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_api_protobuf_dogs_proto_goTypes = []any{
 	(*GetRandomDogImageRequest)(nil),  // 0: go_platform.dogs.GetRandomDogImageRequest
 	(*GetRandomDogImageResponse)(nil), // 1: go_platform.dogs.GetRandomDogImageResponse
-	(*ErrorResponse)(nil),             // 2: go_platform.dogs.ErrorResponse
-	(*timestamppb.Timestamp)(nil),     // 3: google.protobuf.Timestamp
+	(*Dog)(nil),                       // 2: go_platform.dogs.Dog
+	(*GetDogRequest)(nil),             // 3: go_platform.dogs.GetDogRequest
+	(*ListDogsRequest)(nil),           // 4: go_platform.dogs.ListDogsRequest
+	(*ListDogsResponse)(nil),          // 5: go_platform.dogs.ListDogsResponse
+	(*GetRandomDogImagesRequest)(nil), // 6: go_platform.dogs.GetRandomDogImagesRequest
+	(*DogImageResult)(nil),            // 7: go_platform.dogs.DogImageResult
+	(*ListBreedsRequest)(nil),         // 8: go_platform.dogs.ListBreedsRequest
+	(*Breed)(nil),                     // 9: go_platform.dogs.Breed
+	(*ListBreedsResponse)(nil),        // 10: go_platform.dogs.ListBreedsResponse
+	(*ErrorResponse)(nil),             // 11: go_platform.dogs.ErrorResponse
+	(*timestamppb.Timestamp)(nil),     // 12: google.protobuf.Timestamp
 }
 var file_api_protobuf_dogs_proto_depIdxs = []int32{
-	3, // 0: go_platform.dogs.GetRandomDogImageResponse.created_at:type_name -> google.protobuf.Timestamp
-	0, // 1: go_platform.dogs.DogService.GetRandomDogImage:input_type -> go_platform.dogs.GetRandomDogImageRequest
-	1, // 2: go_platform.dogs.DogService.GetRandomDogImage:output_type -> go_platform.dogs.GetRandomDogImageResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	12, // 0: go_platform.dogs.GetRandomDogImageResponse.created_at:type_name -> google.protobuf.Timestamp
+	12, // 1: go_platform.dogs.Dog.created_at:type_name -> google.protobuf.Timestamp
+	12, // 2: go_platform.dogs.ListDogsRequest.from:type_name -> google.protobuf.Timestamp
+	12, // 3: go_platform.dogs.ListDogsRequest.to:type_name -> google.protobuf.Timestamp
+	2,  // 4: go_platform.dogs.ListDogsResponse.dogs:type_name -> go_platform.dogs.Dog
+	9,  // 5: go_platform.dogs.ListBreedsResponse.breeds:type_name -> go_platform.dogs.Breed
+	0,  // 6: go_platform.dogs.DogService.GetRandomDogImage:input_type -> go_platform.dogs.GetRandomDogImageRequest
+	3,  // 7: go_platform.dogs.DogService.GetDog:input_type -> go_platform.dogs.GetDogRequest
+	4,  // 8: go_platform.dogs.DogService.ListDogs:input_type -> go_platform.dogs.ListDogsRequest
+	6,  // 9: go_platform.dogs.DogService.GetRandomDogImages:input_type -> go_platform.dogs.GetRandomDogImagesRequest
+	8,  // 10: go_platform.dogs.DogService.ListBreeds:input_type -> go_platform.dogs.ListBreedsRequest
+	1,  // 11: go_platform.dogs.DogService.GetRandomDogImage:output_type -> go_platform.dogs.GetRandomDogImageResponse
+	2,  // 12: go_platform.dogs.DogService.GetDog:output_type -> go_platform.dogs.Dog
+	5,  // 13: go_platform.dogs.DogService.ListDogs:output_type -> go_platform.dogs.ListDogsResponse
+	7,  // 14: go_platform.dogs.DogService.GetRandomDogImages:output_type -> go_platform.dogs.DogImageResult
+	10, // 15: go_platform.dogs.DogService.ListBreeds:output_type -> go_platform.dogs.ListBreedsResponse
+	11, // [11:16] is the sub-list for method output_type
+	6,  // [6:11] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_api_protobuf_dogs_proto_init() }
@@ -267,9 +964,9 @@ func file_api_protobuf_dogs_proto_init() {
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_api_protobuf_dogs_proto_rawDesc,
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_protobuf_dogs_proto_rawDesc), len(file_api_protobuf_dogs_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -278,7 +975,6 @@ func file_api_protobuf_dogs_proto_init() {
 		MessageInfos:      file_api_protobuf_dogs_proto_msgTypes,
 	}.Build()
 	File_api_protobuf_dogs_proto = out.File
-	file_api_protobuf_dogs_proto_rawDesc = nil
 	file_api_protobuf_dogs_proto_goTypes = nil
 	file_api_protobuf_dogs_proto_depIdxs = nil
 }
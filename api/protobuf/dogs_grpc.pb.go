@@ -19,7 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DogService_GetRandomDogImage_FullMethodName = "/go_platform.dogs.DogService/GetRandomDogImage"
+	DogService_GetRandomDogImage_FullMethodName  = "/go_platform.dogs.DogService/GetRandomDogImage"
+	DogService_GetDog_FullMethodName             = "/go_platform.dogs.DogService/GetDog"
+	DogService_ListDogs_FullMethodName           = "/go_platform.dogs.DogService/ListDogs"
+	DogService_GetRandomDogImages_FullMethodName = "/go_platform.dogs.DogService/GetRandomDogImages"
+	DogService_ListBreeds_FullMethodName         = "/go_platform.dogs.DogService/ListBreeds"
 )
 
 // DogServiceClient is the client API for DogService service.
@@ -29,6 +33,10 @@ const (
 // Dog service definition
 type DogServiceClient interface {
 	GetRandomDogImage(ctx context.Context, in *GetRandomDogImageRequest, opts ...grpc.CallOption) (*GetRandomDogImageResponse, error)
+	GetDog(ctx context.Context, in *GetDogRequest, opts ...grpc.CallOption) (*Dog, error)
+	ListDogs(ctx context.Context, in *ListDogsRequest, opts ...grpc.CallOption) (*ListDogsResponse, error)
+	GetRandomDogImages(ctx context.Context, in *GetRandomDogImagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DogImageResult], error)
+	ListBreeds(ctx context.Context, in *ListBreedsRequest, opts ...grpc.CallOption) (*ListBreedsResponse, error)
 }
 
 type dogServiceClient struct {
@@ -49,6 +57,55 @@ func (c *dogServiceClient) GetRandomDogImage(ctx context.Context, in *GetRandomD
 	return out, nil
 }
 
+func (c *dogServiceClient) GetDog(ctx context.Context, in *GetDogRequest, opts ...grpc.CallOption) (*Dog, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Dog)
+	err := c.cc.Invoke(ctx, DogService_GetDog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dogServiceClient) ListDogs(ctx context.Context, in *ListDogsRequest, opts ...grpc.CallOption) (*ListDogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDogsResponse)
+	err := c.cc.Invoke(ctx, DogService_ListDogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dogServiceClient) GetRandomDogImages(ctx context.Context, in *GetRandomDogImagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DogImageResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DogService_ServiceDesc.Streams[0], DogService_GetRandomDogImages_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetRandomDogImagesRequest, DogImageResult]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DogService_GetRandomDogImagesClient = grpc.ServerStreamingClient[DogImageResult]
+
+func (c *dogServiceClient) ListBreeds(ctx context.Context, in *ListBreedsRequest, opts ...grpc.CallOption) (*ListBreedsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBreedsResponse)
+	err := c.cc.Invoke(ctx, DogService_ListBreeds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DogServiceServer is the server API for DogService service.
 // All implementations must embed UnimplementedDogServiceServer
 // for forward compatibility.
@@ -56,6 +113,10 @@ func (c *dogServiceClient) GetRandomDogImage(ctx context.Context, in *GetRandomD
 // Dog service definition
 type DogServiceServer interface {
 	GetRandomDogImage(context.Context, *GetRandomDogImageRequest) (*GetRandomDogImageResponse, error)
+	GetDog(context.Context, *GetDogRequest) (*Dog, error)
+	ListDogs(context.Context, *ListDogsRequest) (*ListDogsResponse, error)
+	GetRandomDogImages(*GetRandomDogImagesRequest, grpc.ServerStreamingServer[DogImageResult]) error
+	ListBreeds(context.Context, *ListBreedsRequest) (*ListBreedsResponse, error)
 	mustEmbedUnimplementedDogServiceServer()
 }
 
@@ -69,6 +130,18 @@ type UnimplementedDogServiceServer struct{}
 func (UnimplementedDogServiceServer) GetRandomDogImage(context.Context, *GetRandomDogImageRequest) (*GetRandomDogImageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRandomDogImage not implemented")
 }
+func (UnimplementedDogServiceServer) GetDog(context.Context, *GetDogRequest) (*Dog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDog not implemented")
+}
+func (UnimplementedDogServiceServer) ListDogs(context.Context, *ListDogsRequest) (*ListDogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDogs not implemented")
+}
+func (UnimplementedDogServiceServer) GetRandomDogImages(*GetRandomDogImagesRequest, grpc.ServerStreamingServer[DogImageResult]) error {
+	return status.Errorf(codes.Unimplemented, "method GetRandomDogImages not implemented")
+}
+func (UnimplementedDogServiceServer) ListBreeds(context.Context, *ListBreedsRequest) (*ListBreedsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBreeds not implemented")
+}
 func (UnimplementedDogServiceServer) mustEmbedUnimplementedDogServiceServer() {}
 func (UnimplementedDogServiceServer) testEmbeddedByValue()                    {}
 
@@ -108,6 +181,71 @@ func _DogService_GetRandomDogImage_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DogService_GetDog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DogServiceServer).GetDog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DogService_GetDog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DogServiceServer).GetDog(ctx, req.(*GetDogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DogService_ListDogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DogServiceServer).ListDogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DogService_ListDogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DogServiceServer).ListDogs(ctx, req.(*ListDogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DogService_GetRandomDogImages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRandomDogImagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DogServiceServer).GetRandomDogImages(m, &grpc.GenericServerStream[GetRandomDogImagesRequest, DogImageResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DogService_GetRandomDogImagesServer = grpc.ServerStreamingServer[DogImageResult]
+
+func _DogService_ListBreeds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBreedsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DogServiceServer).ListBreeds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DogService_ListBreeds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DogServiceServer).ListBreeds(ctx, req.(*ListBreedsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DogService_ServiceDesc is the grpc.ServiceDesc for DogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -119,7 +257,25 @@ var DogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRandomDogImage",
 			Handler:    _DogService_GetRandomDogImage_Handler,
 		},
+		{
+			MethodName: "GetDog",
+			Handler:    _DogService_GetDog_Handler,
+		},
+		{
+			MethodName: "ListDogs",
+			Handler:    _DogService_ListDogs_Handler,
+		},
+		{
+			MethodName: "ListBreeds",
+			Handler:    _DogService_ListBreeds_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetRandomDogImages",
+			Handler:       _DogService_GetRandomDogImages_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/protobuf/dogs.proto",
 }
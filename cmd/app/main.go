@@ -2,14 +2,20 @@ package main
 
 import (
 	"context"
+	"go-platform/internal/clients/providers"
 	restclientexample "go-platform/internal/clients/rest-client-example"
 	"go-platform/internal/clients/s3"
 	grpc "go-platform/internal/gprc"
 	"go-platform/internal/handlers"
+	"go-platform/internal/services/breeds"
 	"go-platform/internal/services/dogs"
+	"go-platform/internal/services/jobs"
+	"go-platform/internal/services/outbox"
+	redisStorage "go-platform/internal/storages/redis"
 	"go-platform/pkg/broker/nats"
 	"go-platform/pkg/cache/redis"
 	"go-platform/pkg/config"
+	"go-platform/pkg/httpclient"
 	"go-platform/pkg/logger"
 	"go-platform/pkg/metrics"
 	"go-platform/pkg/server"
@@ -58,14 +64,7 @@ func main() {
 	}
 
 	// Initialize S3 client
-	s3Client, err := s3.NewClientS3(
-		cfg.S3.KeyID,
-		cfg.S3.KeySecret,
-		cfg.S3.Bucket,
-		cfg.S3.BaseEndpoint,
-		cfg.S3.BasePublicEndpoint,
-		cfg.S3.Region,
-	)
+	s3Client, err := s3.NewClientS3(cfg.S3)
 	if err != nil {
 		log.Error("Failed to connect to S3", "error", err)
 		panic(err)
@@ -83,7 +82,7 @@ func main() {
 	slog.Info("Cache connected successfully")
 
 	// Initialize broker
-	broker, err := nats.NewNATS(ctx, cfg.NATS.URL)
+	broker, err := nats.NewNATS(ctx, cfg.NATS.URL, cfg.NATS.SubjectPrefix)
 	if err != nil {
 		log.Error("Failed to connect to NATS", "error", err)
 		panic(err)
@@ -91,25 +90,82 @@ func main() {
 
 	slog.Info("Broker connected successfully")
 
-	// Initialize dog API client
-	dogsAPI := restclientexample.NewDogAPI()
+	// Events go through JetStream when enabled so they survive consumer downtime
+	var publisher outbox.Publisher = broker
+	if cfg.NATS.JetStream.Enabled {
+		jetStream, err := nats.NewJetStream(ctx, broker, cfg.NATS.JetStream)
+		if err != nil {
+			log.Error("Failed to initialize JetStream", "error", err)
+			panic(err)
+		}
+		publisher = jetStream
+
+		slog.Info("JetStream initialized successfully")
+	} else {
+		slog.Warn("JetStream is disabled, core NATS does not deduplicate relayed outbox messages by Nats-Msg-Id")
+	}
+
+	// Outbound calls share retries, circuit breakers and the bulkhead
+	httpClient := httpclient.NewClient(cfg.HTTPClient, metricsInstance.HTTPClient)
+
+	// Initialize dog image providers
+	var dogsAPI dogs.DogAPIClient
+	dogsAPI, err = providers.NewProvider(cfg.DogAPI, httpClient)
+	if err != nil {
+		log.Error("Failed to initialize image providers", "error", err)
+		panic(err)
+	}
+
+	// Put read-through cache in front of the upstreams
+	var (
+		dogsS3           dogs.ClientS3     = s3Client
+		dogsRepository   dogs.Repository   = storage.Repository
+		breedsRepository breeds.Repository = storage.Repository
+	)
+	if cfg.Cache.Enabled {
+		readCache := redis.NewCache(cache, cfg.Cache.Prefix, metricsInstance.Cache)
+		dogsAPI = dogs.NewCachedDogAPI(dogsAPI, readCache, cfg.Cache)
+		// a cached URL must stay valid for a while after it is served
+		cacheCfg := cfg.Cache
+		cacheCfg.S3URLTTL = min(cacheCfg.S3URLTTL, cfg.S3.PresignGetExpiry/2)
+		dogsS3 = dogs.NewCachedClientS3(dogsS3, readCache, cacheCfg)
+		dogsRepository = dogs.NewCachedRepository(dogsRepository, readCache, cfg.Cache)
+		breedsRepository = breeds.NewCachedRepository(breedsRepository, readCache, cfg.Cache)
+		slog.Info("Cache layer enabled")
+	}
+
+	// Breed catalog synced from dog.ceo, validates breeds before the providers are called
+	breedsService := breeds.NewBreedsService(restclientexample.NewDogAPI(cfg.DogAPI.BaseURL, httpClient), breedsRepository, cfg.Breeds)
 
 	// Initialize dogs service
-	dogsService := dogs.NewDogsService(dogsAPI, s3Client, storage.Repository)
+	dogsService := dogs.NewDogsService(dogsAPI, dogsS3, dogsRepository, breedsService, cfg.DogAPI)
 
-	// Initialize handlers
-	handler := handlers.NewHandler(dogsService)
+	// Initialize async ingestion jobs, workers are started by the server
+	jobsRepository := redisStorage.NewJobsRepository(cache, cfg.Jobs.TTL, metricsInstance.Database)
+	jobsService := jobs.NewJobsService(dogsService, jobsRepository, cfg.Jobs)
 
-	// gRPC server
-	grpcServer := grpc.NewServer(dogsService)
+	// Initialize handlers
+	handler := handlers.NewHandler(dogsService, jobsService, breedsService)
 
 	// Create unified server first to get metrics
-	srv, err := server.NewServer(cfg, nil, grpcServer, metricsInstance)
+	srv, err := server.NewServer(cfg, nil, nil, metricsInstance)
 	if err != nil {
 		log.Error("Failed to create server", "error", err)
 		panic(err)
 	}
 
+	// gRPC server, its calls are cancelled with the server requests on shutdown
+	srv.GRPC = grpc.NewServer(srv.RequestContext(), dogsService, breedsService, metricsInstance.GRPC)
+
+	// Relay events written by the service to the outbox
+	srv.Workers = append(srv.Workers, outbox.NewRelay(storage.Repository, publisher, cfg.Outbox))
+
+	// Keep the breed catalog in sync
+	srv.Workers = append(srv.Workers, breedsService)
+
+	// Process queued ingestion jobs
+	srv.Workers = append(srv.Workers, jobsService)
+
 	// Initialize router with metrics
 	router := handlers.InitRouter(handler, srv.Metrics.HTTP)
 
@@ -0,0 +1,115 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+
+	"go-platform/internal/models/dogs"
+	"go-platform/pkg/httpclient"
+	"go-platform/pkg/utils/errs"
+)
+
+type weightedProvider struct {
+	name     string
+	weight   int
+	provider Provider
+}
+
+// fallback tries its providers in a weighted random order until one succeeds,
+// so heavier providers serve most requests and the others take over when they fail
+type fallback struct {
+	providers []weightedProvider
+}
+
+func newFallback(providers []weightedProvider) *fallback {
+	return &fallback{providers: providers}
+}
+
+func (f *fallback) GetRandomDogImageByBreed(ctx context.Context, breed, subBreed string) (string, error) {
+	var imageURL string
+	err := f.try(ctx, "get_image", func(p Provider) error {
+		var err error
+		imageURL, err = p.GetRandomDogImageByBreed(ctx, breed, subBreed)
+		return err
+	})
+	return imageURL, err
+}
+
+func (f *fallback) GetRandomDogImagesByBreed(ctx context.Context, breed, subBreed string, n int) ([]string, error) {
+	var imageURLs []string
+	err := f.try(ctx, "get_images", func(p Provider) error {
+		var err error
+		imageURLs, err = p.GetRandomDogImagesByBreed(ctx, breed, subBreed, n)
+		return err
+	})
+	return imageURLs, err
+}
+
+// DownloadDogImage is tried on every provider as well: an image URL is usually only
+// understood by the provider that issued it, and others fail fast on it
+func (f *fallback) DownloadDogImage(ctx context.Context, imageURL string) (io.ReadCloser, int64, error) {
+	var (
+		body io.ReadCloser
+		size int64
+	)
+	err := f.try(ctx, "download", func(p Provider) error {
+		var err error
+		body, size, err = p.DownloadDogImage(ctx, imageURL)
+		return err
+	})
+	return body, size, err
+}
+
+// try calls fn with each provider until one succeeds or ctx ends, a cancelled call is not a provider failure.
+// The breed is reported as not found only when every provider said so.
+func (f *fallback) try(ctx context.Context, operation string, fn func(Provider) error) error {
+	var failures []error
+	for _, p := range f.order() {
+		err := fn(p.provider)
+		if err == nil {
+			return nil
+		}
+		if reason := httpclient.CancellationReason(ctx, err); reason != "" {
+			slog.WarnContext(ctx, "Image provider call cancelled", "provider", p.name, "operation", operation, "reason", reason)
+			return err
+		}
+		if !errors.Is(err, dogs.ErrBreedNotFound) {
+			failures = append(failures, fmt.Errorf("%s: %w", p.name, err))
+		}
+		slog.WarnContext(ctx, "Image provider failed, falling back", "provider", p.name, "operation", operation, "error", err)
+	}
+
+	if len(failures) == 0 {
+		return dogs.ErrBreedNotFound
+	}
+	return errs.Errorf(errs.UpstreamUnavailable, "all image providers failed: %w", errors.Join(failures...))
+}
+
+// order returns the providers shuffled so that each position is drawn proportionally to weight
+func (f *fallback) order() []weightedProvider {
+	remaining := append([]weightedProvider(nil), f.providers...)
+	total := 0
+	for _, p := range remaining {
+		total += p.weight
+	}
+
+	ordered := make([]weightedProvider, 0, len(remaining))
+	for len(remaining) > 0 {
+		pick := rand.IntN(total)
+		for i, p := range remaining {
+			if pick < p.weight {
+				ordered = append(ordered, p)
+				total -= p.weight
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+			pick -= p.weight
+		}
+	}
+
+	return ordered
+}
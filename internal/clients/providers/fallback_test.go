@@ -0,0 +1,115 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"go-platform/internal/models/dogs"
+	"go-platform/pkg/utils/errs"
+)
+
+// stubProvider answers every call with imageURL or err and counts the calls
+type stubProvider struct {
+	imageURL string
+	err      error
+	calls    int
+}
+
+func (p *stubProvider) GetRandomDogImageByBreed(context.Context, string, string) (string, error) {
+	p.calls++
+	return p.imageURL, p.err
+}
+
+func (p *stubProvider) GetRandomDogImagesByBreed(context.Context, string, string, int) ([]string, error) {
+	p.calls++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return []string{p.imageURL}, nil
+}
+
+func (p *stubProvider) DownloadDogImage(context.Context, string) (io.ReadCloser, int64, error) {
+	p.calls++
+	return nil, 0, p.err
+}
+
+func TestFallbackOrderFollowsWeights(t *testing.T) {
+	f := newFallback([]weightedProvider{
+		{name: "heavy", weight: 3, provider: &stubProvider{}},
+		{name: "light", weight: 1, provider: &stubProvider{}},
+	})
+
+	const draws = 4000
+	first := map[string]int{}
+	for range draws {
+		order := f.order()
+		if len(order) != 2 || order[0].name == order[1].name {
+			t.Fatalf("order() = %v, want each provider once", order)
+		}
+		first[order[0].name]++
+	}
+
+	// heavy goes first 3 times out of 4
+	if share := float64(first["heavy"]) / draws; share < 0.7 || share > 0.8 {
+		t.Fatalf("heavy provider first in %.2f of the orders, want about 0.75", share)
+	}
+}
+
+func TestFallbackTry(t *testing.T) {
+	upstreamDown := errs.New(errs.UpstreamUnavailable, "provider down")
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantURL   string
+		wantErr   error
+		wantKind  errs.Kind
+		wantCalls []int // calls of each provider, -1 when it depends on the drawn order, nil for a single call in all
+	}{
+		{name: "falls back to the next provider", errs: []error{upstreamDown, nil}, wantURL: "https://images/1.jpg", wantCalls: []int{-1, 1}},
+		{name: "all providers said not found", errs: []error{dogs.ErrBreedNotFound, dogs.ErrBreedNotFound}, wantErr: dogs.ErrBreedNotFound, wantCalls: []int{1, 1}},
+		{name: "not found and a failure", errs: []error{dogs.ErrBreedNotFound, upstreamDown}, wantKind: errs.UpstreamUnavailable, wantCalls: []int{1, 1}},
+		{name: "cancelled call stops the fallback", errs: []error{context.Canceled, context.Canceled}, wantErr: context.Canceled, wantKind: errs.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubs := make([]*stubProvider, len(tt.errs))
+			weighted := make([]weightedProvider, len(tt.errs))
+			for i, err := range tt.errs {
+				stubs[i] = &stubProvider{imageURL: "https://images/1.jpg", err: err}
+				weighted[i] = weightedProvider{name: fmt.Sprintf("provider-%d", i), weight: 1, provider: stubs[i]}
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if errors.Is(tt.errs[0], context.Canceled) {
+				cancel()
+			}
+
+			imageURL, err := newFallback(weighted).GetRandomDogImageByBreed(ctx, "hound", "")
+			if tt.wantURL != "" && (imageURL != tt.wantURL || err != nil) {
+				t.Fatalf("GetRandomDogImageByBreed() = %q, %v, want %q", imageURL, err, tt.wantURL)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetRandomDogImageByBreed() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantKind != errs.Unknown && errs.KindOf(err) != tt.wantKind {
+				t.Fatalf("GetRandomDogImageByBreed() error kind = %v, want %v", errs.KindOf(err), tt.wantKind)
+			}
+
+			calls := 0
+			for i, stub := range stubs {
+				calls += stub.calls
+				if tt.wantCalls != nil && tt.wantCalls[i] >= 0 && stub.calls != tt.wantCalls[i] {
+					t.Fatalf("provider-%d calls = %d, want %d", i, stub.calls, tt.wantCalls[i])
+				}
+			}
+			if tt.wantCalls == nil && calls != 1 {
+				t.Fatalf("provider calls = %d, want 1", calls)
+			}
+		})
+	}
+}
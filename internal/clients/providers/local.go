@@ -0,0 +1,135 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"math/rand/v2"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go-platform/internal/models/dogs"
+)
+
+// imageExtensions are the files served by the local provider
+var imageExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".gif": true}
+
+// LocalProvider serves images from disk for offline development.
+// Every breed is a directory under dir with its sub-breeds as nested directories,
+// images are returned as file:// URLs.
+type LocalProvider struct {
+	dir string
+}
+
+// NewLocalProvider creates a provider serving the breed directories under dir
+func NewLocalProvider(dir string) (*LocalProvider, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve local image directory: %w", err)
+	}
+
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open local image directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("local image path %s is not a directory", abs)
+	}
+
+	return &LocalProvider{dir: abs}, nil
+}
+
+// GetRandomDogImageByBreed gets a random dog image for a specific breed
+func (l *LocalProvider) GetRandomDogImageByBreed(ctx context.Context, breed, subBreed string) (string, error) {
+	imageURLs, err := l.GetRandomDogImagesByBreed(ctx, breed, subBreed, 1)
+	if err != nil {
+		return "", err
+	}
+
+	return imageURLs[0], nil
+}
+
+// GetRandomDogImagesByBreed gets up to n distinct random dog images for a specific breed
+func (l *LocalProvider) GetRandomDogImagesByBreed(ctx context.Context, breed, subBreed string, n int) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// a breed and a sub-breed are single directory names, anything else could escape dir
+	if !isDirName(breed) || (subBreed != "" && !isDirName(subBreed)) {
+		return nil, dogs.ErrBreedNotFound
+	}
+	breedDir := filepath.Join(l.dir, breed, subBreed)
+
+	entries, err := os.ReadDir(breedDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, dogs.ErrBreedNotFound
+		}
+		return nil, fmt.Errorf("failed to read breed directory: %w", err)
+	}
+
+	var images []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && imageExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			images = append(images, entry.Name())
+		}
+	}
+	if len(images) == 0 {
+		return nil, dogs.ErrBreedNotFound
+	}
+
+	rand.Shuffle(len(images), func(i, j int) { images[i], images[j] = images[j], images[i] })
+	images = images[:min(n, len(images))]
+
+	imageURLs := make([]string, 0, len(images))
+	for _, name := range images {
+		u := url.URL{Scheme: "file", Path: filepath.ToSlash(filepath.Join(breedDir, name))}
+		imageURLs = append(imageURLs, u.String())
+	}
+
+	slog.InfoContext(ctx, "Successfully retrieved random dog images", "breed", breed, "count", len(imageURLs))
+	return imageURLs, nil
+}
+
+// DownloadDogImage opens a file:// image URL under the provider directory.
+// The caller must close the body.
+func (l *LocalProvider) DownloadDogImage(ctx context.Context, imageURL string) (io.ReadCloser, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	u, err := url.Parse(imageURL)
+	if err != nil || u.Scheme != "file" {
+		return nil, 0, fmt.Errorf("unsupported image URL %s", imageURL)
+	}
+
+	path := filepath.FromSlash(u.Path)
+	rel, err := filepath.Rel(l.dir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, 0, fmt.Errorf("image %s is outside the local image directory", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open image: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, 0, fmt.Errorf("failed to stat image: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Started image download", "url", imageURL, "size_bytes", info.Size())
+	return file, info.Size(), nil
+}
+
+func isDirName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
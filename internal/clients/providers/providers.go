@@ -0,0 +1,112 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+
+	restclientexample "go-platform/internal/clients/rest-client-example"
+	"go-platform/pkg/config"
+	"go-platform/pkg/httpclient"
+)
+
+// Provider is an upstream source of dog images
+type Provider interface {
+	GetRandomDogImageByBreed(ctx context.Context, breed, subBreed string) (string, error)
+	GetRandomDogImagesByBreed(ctx context.Context, breed, subBreed string, n int) ([]string, error)
+	DownloadDogImage(ctx context.Context, imageURL string) (io.ReadCloser, int64, error)
+}
+
+// Factory creates a provider from the dog API config on the shared outbound HTTP client
+type Factory func(cfg config.DogAPIConfig, client *httpclient.Client) (Provider, error)
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]Factory{
+		"dogceo": func(cfg config.DogAPIConfig, client *httpclient.Client) (Provider, error) {
+			return restclientexample.NewDogAPI(cfg.BaseURL, client), nil
+		},
+		"template": func(cfg config.DogAPIConfig, client *httpclient.Client) (Provider, error) {
+			return NewTemplateProvider(cfg.TemplateURL, cfg.TemplateField, client)
+		},
+		"local": func(cfg config.DogAPIConfig, _ *httpclient.Client) (Provider, error) {
+			return NewLocalProvider(cfg.LocalDir)
+		},
+	}
+)
+
+// Register makes a provider available by name, an existing provider with the same name is replaced
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	registry[name] = factory
+}
+
+// NewProvider creates the providers listed in cfg.Providers.
+// A single provider is returned as is, several are wrapped in a weighted fallback.
+func NewProvider(cfg config.DogAPIConfig, client *httpclient.Client) (Provider, error) {
+	specs, err := parseProviders(cfg.Providers)
+	if err != nil {
+		return nil, err
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	weighted := make([]weightedProvider, 0, len(specs))
+	for _, spec := range specs {
+		factory, ok := registry[spec.name]
+		if !ok {
+			return nil, fmt.Errorf("unknown image provider %q", spec.name)
+		}
+		provider, err := factory(cfg, client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create image provider %q: %w", spec.name, err)
+		}
+		weighted = append(weighted, weightedProvider{name: spec.name, weight: spec.weight, provider: provider})
+		slog.Info("Image provider initialized", "provider", spec.name, "weight", spec.weight)
+	}
+
+	if len(weighted) == 1 {
+		return weighted[0].provider, nil
+	}
+
+	return newFallback(weighted), nil
+}
+
+type providerSpec struct {
+	name   string
+	weight int
+}
+
+// parseProviders parses a comma separated list of name[:weight], the weight defaults to 1
+func parseProviders(list string) ([]providerSpec, error) {
+	var specs []providerSpec
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		spec := providerSpec{name: item, weight: 1}
+		if name, weight, ok := strings.Cut(item, ":"); ok {
+			w, err := strconv.Atoi(weight)
+			if err != nil || w <= 0 {
+				return nil, fmt.Errorf("invalid weight of image provider %q", item)
+			}
+			spec = providerSpec{name: name, weight: w}
+		}
+		specs = append(specs, spec)
+	}
+
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no image providers configured")
+	}
+
+	return specs, nil
+}
@@ -0,0 +1,35 @@
+package providers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseProviders(t *testing.T) {
+	tests := []struct {
+		name    string
+		list    string
+		want    []providerSpec
+		wantErr bool
+	}{
+		{name: "default weight", list: "dogceo", want: []providerSpec{{name: "dogceo", weight: 1}}},
+		{name: "weighted list", list: " dogceo:3, local ,", want: []providerSpec{{name: "dogceo", weight: 3}, {name: "local", weight: 1}}},
+		{name: "zero weight", list: "dogceo:0", wantErr: true},
+		{name: "negative weight", list: "dogceo:-2", wantErr: true},
+		{name: "weight is not a number", list: "dogceo:heavy", wantErr: true},
+		{name: "empty weight", list: "dogceo:", wantErr: true},
+		{name: "empty list", list: " , ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProviders(tt.list)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseProviders(%q) error = %v, wantErr %v", tt.list, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parseProviders(%q) = %v, want %v", tt.list, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go-platform/internal/models/dogs"
+	"go-platform/pkg/httpclient"
+	"go-platform/pkg/utils/errs"
+
+	"resty.dev/v3"
+)
+
+// TemplateProvider gets images from any JSON API addressed by a URL template.
+// {breed}, {sub_breed} and {count} in the template are substituted and the image URLs are read
+// from the response field at a dot separated path, either a string or a list of strings.
+type TemplateProvider struct {
+	rClient  *resty.Client
+	template string
+	field    []string
+}
+
+// NewTemplateProvider creates a provider requesting urlTemplate and reading image URLs from field
+func NewTemplateProvider(urlTemplate, field string, client *httpclient.Client) (*TemplateProvider, error) {
+	if urlTemplate == "" {
+		return nil, fmt.Errorf("DOG_API_TEMPLATE_URL is required for the template provider")
+	}
+	if !strings.Contains(urlTemplate, "{breed}") {
+		return nil, fmt.Errorf("template URL must contain {breed}")
+	}
+	if field == "" {
+		return nil, fmt.Errorf("DOG_API_TEMPLATE_FIELD is required for the template provider")
+	}
+
+	return &TemplateProvider{
+		rClient: client.Resty().
+			SetHeader("Accept", "application/json"),
+		template: urlTemplate,
+		field:    strings.Split(field, "."),
+	}, nil
+}
+
+// GetRandomDogImageByBreed gets a random dog image for a specific breed
+func (t *TemplateProvider) GetRandomDogImageByBreed(ctx context.Context, breed, subBreed string) (string, error) {
+	imageURLs, err := t.GetRandomDogImagesByBreed(ctx, breed, subBreed, 1)
+	if err != nil {
+		return "", err
+	}
+
+	return imageURLs[0], nil
+}
+
+// GetRandomDogImagesByBreed gets up to n random dog images for a specific breed
+func (t *TemplateProvider) GetRandomDogImagesByBreed(ctx context.Context, breed, subBreed string, n int) ([]string, error) {
+	// without a {sub_breed} placeholder the sub-breed is addressed dog.ceo style as {breed}/{sub_breed}
+	breedParam := url.PathEscape(breed)
+	if subBreed != "" && !strings.Contains(t.template, "{sub_breed}") {
+		breedParam += "/" + url.PathEscape(subBreed)
+	}
+	requestURL := strings.NewReplacer(
+		"{breed}", breedParam,
+		"{sub_breed}", url.PathEscape(subBreed),
+		"{count}", strconv.Itoa(n),
+	).Replace(t.template)
+
+	res, err := t.rClient.R().SetContext(ctx).Get(requestURL)
+	if err != nil {
+		return nil, errs.Errorf(errs.UpstreamUnavailable, "failed to send request: %w", err)
+	}
+
+	if res.StatusCode() == http.StatusNotFound {
+		return nil, dogs.ErrBreedNotFound
+	}
+
+	if res.IsError() {
+		slog.ErrorContext(ctx, "Failed to get random dog images", "breed", breed, "count", n, "status", res.StatusCode())
+		return nil, errs.Errorf(errs.UpstreamUnavailable, "received non-200 response status: %d", res.StatusCode())
+	}
+
+	var body any
+	if err := json.Unmarshal(res.Bytes(), &body); err != nil {
+		return nil, errs.Errorf(errs.UpstreamUnavailable, "failed to decode response: %w", err)
+	}
+
+	imageURLs, err := t.imageURLs(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(imageURLs) == 0 {
+		return nil, dogs.ErrBreedNotFound
+	}
+	if len(imageURLs) > n {
+		imageURLs = imageURLs[:n]
+	}
+
+	slog.InfoContext(ctx, "Successfully retrieved random dog images", "breed", breed, "count", len(imageURLs))
+	return imageURLs, nil
+}
+
+// imageURLs reads the configured field of the decoded response
+func (t *TemplateProvider) imageURLs(body any) ([]string, error) {
+	value := body
+	for _, key := range t.field {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("response field %q is not an object", key)
+		}
+		if value, ok = object[key]; !ok {
+			return nil, fmt.Errorf("response has no field %q", strings.Join(t.field, "."))
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case []any:
+		imageURLs := make([]string, 0, len(v))
+		for _, item := range v {
+			imageURL, ok := item.(string)
+			if !ok {
+				return nil, errors.New("response image list holds a non-string value")
+			}
+			imageURLs = append(imageURLs, imageURL)
+		}
+		return imageURLs, nil
+	default:
+		return nil, fmt.Errorf("response field %q is neither a URL nor a list of URLs", strings.Join(t.field, "."))
+	}
+}
+
+// DownloadDogImage opens the image at the given URL and returns its body as a stream with
+// its size, -1 when the upstream does not send it. The caller must close the body.
+func (t *TemplateProvider) DownloadDogImage(ctx context.Context, imageURL string) (io.ReadCloser, int64, error) {
+	res, err := t.rClient.R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		Get(imageURL)
+	if err != nil {
+		return nil, 0, errs.Errorf(errs.UpstreamUnavailable, "failed to download image: %w", err)
+	}
+
+	if res.IsError() {
+		res.Body.Close()
+		slog.ErrorContext(ctx, "Failed to download image", "url", imageURL, "status", res.StatusCode())
+		return nil, 0, errs.Errorf(errs.UpstreamUnavailable, "received non-200 response status: %d", res.StatusCode())
+	}
+
+	size := res.RawResponse.ContentLength
+	slog.InfoContext(ctx, "Started image download", "url", imageURL, "size_bytes", size)
+
+	return res.Body, size, nil
+}
@@ -0,0 +1,97 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-platform/pkg/config"
+	"go-platform/pkg/httpclient"
+	"go-platform/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestTemplateProviderImageURLs(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   string
+		body    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "string field", field: "message", body: `{"message": "https://images/1.jpg"}`, want: []string{"https://images/1.jpg"}},
+		{name: "nested list", field: "data.images", body: `{"data": {"images": ["https://images/1.jpg", "https://images/2.jpg"]}}`, want: []string{"https://images/1.jpg", "https://images/2.jpg"}},
+		{name: "empty list", field: "message", body: `{"message": []}`, want: []string{}},
+		{name: "non-string list item", field: "message", body: `{"message": ["https://images/1.jpg", 2]}`, wantErr: true},
+		{name: "missing field", field: "data.images", body: `{"data": {}}`, wantErr: true},
+		{name: "path through a non-object", field: "data.images", body: `{"data": "https://images/1.jpg"}`, wantErr: true},
+		{name: "neither URL nor list", field: "message", body: `{"message": {"url": "https://images/1.jpg"}}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body any
+			if err := json.Unmarshal([]byte(tt.body), &body); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			provider := &TemplateProvider{field: strings.Split(tt.field, ".")}
+
+			got, err := provider.imageURLs(body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("imageURLs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("imageURLs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateProviderRequestURL(t *testing.T) {
+	var gotPath string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"message": ["https://images/1.jpg", "https://images/2.jpg", "https://images/3.jpg"]}`))
+	}))
+	defer upstream.Close()
+
+	client := httpclient.NewClient(config.HTTPClientConfig{MaxConcurrent: 1, BreakerFailures: 5}, metrics.NewHTTPClientMetrics(prometheus.NewRegistry()))
+
+	tests := []struct {
+		name     string
+		template string
+		subBreed string
+		wantPath string
+	}{
+		{name: "sub-breed placeholder", template: "/breeds/{breed}/subs/{sub_breed}/random/{count}", subBreed: "afghan", wantPath: "/breeds/hound/subs/afghan/random/2"},
+		{name: "sub-breed appended to the breed", template: "/breed/{breed}/images/random/{count}", subBreed: "afghan", wantPath: "/breed/hound/afghan/images/random/2"},
+		{name: "no sub-breed", template: "/breed/{breed}/images/random/{count}", wantPath: "/breed/hound/images/random/2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewTemplateProvider(upstream.URL+tt.template, "message", client)
+			if err != nil {
+				t.Fatalf("NewTemplateProvider() error = %v", err)
+			}
+
+			imageURLs, err := provider.GetRandomDogImagesByBreed(context.Background(), "hound", tt.subBreed, 2)
+			if err != nil {
+				t.Fatalf("GetRandomDogImagesByBreed() error = %v", err)
+			}
+			if gotPath != tt.wantPath {
+				t.Fatalf("request path = %q, want %q", gotPath, tt.wantPath)
+			}
+			// the upstream sent more images than asked for
+			if len(imageURLs) != 2 {
+				t.Fatalf("GetRandomDogImagesByBreed() returned %d images, want 2", len(imageURLs))
+			}
+		})
+	}
+}
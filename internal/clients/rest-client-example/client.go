@@ -1,72 +1,148 @@
 package restclientexample
 
 import (
-	"fmt"
+	"context"
+	"go-platform/internal/models/breeds"
 	"go-platform/internal/models/dogs"
+	"go-platform/pkg/httpclient"
+	"go-platform/pkg/utils/errs"
+	"io"
 	"log/slog"
-	"time"
+	"net/http"
+	"strconv"
 
-	"github.com/pkg/errors"
 	"resty.dev/v3"
 )
 
-const timeout = 10 * time.Second
-
 // DogAPI represents a client for the Dog API
 type DogAPI struct {
 	rClient *resty.Client
 }
 
-// NewDogAPI creates a new Dog API client
-func NewDogAPI() *DogAPI {
+// NewDogAPI creates a new client for the dog.ceo API served at baseURL
+func NewDogAPI(baseURL string, client *httpclient.Client) *DogAPI {
 	return &DogAPI{
-		rClient: resty.New().
-			SetTimeout(timeout).
-			SetBaseURL("https://dog.ceo/api").
+		rClient: client.Resty().
+			SetBaseURL(baseURL).
 			SetHeader("Content-Type", "application/json"),
 	}
 }
 
-// GetRandomDogImageByBreed gets a random dog image for a specific breed
-func (d *DogAPI) GetRandomDogImageByBreed(breed string) (string, error) {
+// breedPath is the API path of a breed or of one of its sub-breeds
+func breedPath(subBreed string) string {
+	if subBreed == "" {
+		return "/breed/{breed}"
+	}
+	return "/breed/{breed}/{subBreed}"
+}
+
+// GetRandomDogImageByBreed gets a random dog image for a specific breed and optional sub-breed
+func (d *DogAPI) GetRandomDogImageByBreed(ctx context.Context, breed, subBreed string) (string, error) {
 	var response dogs.DogResponse
 
 	res, err := d.rClient.R().
+		SetContext(ctx).
 		SetPathParam("breed", breed).
+		SetPathParam("subBreed", subBreed).
 		SetResult(&response).
-		Get("/breed/{breed}/images/random")
+		Get(breedPath(subBreed) + "/images/random")
 	if err != nil {
-		return "", errors.Wrap(err, "failed to send request")
+		return "", errs.Errorf(errs.UpstreamUnavailable, "failed to send request: %w", err)
+	}
+
+	if res.StatusCode() == http.StatusNotFound {
+		return "", dogs.ErrBreedNotFound
 	}
 
 	if res.IsError() {
-		slog.Error("Failed to get random dog image", "breed", breed, "status", res.StatusCode())
-		return "", fmt.Errorf("received non-200 response status: %d", res.StatusCode())
+		slog.ErrorContext(ctx, "Failed to get random dog image", "breed", breed, "status", res.StatusCode())
+		return "", errs.Errorf(errs.UpstreamUnavailable, "received non-200 response status: %d", res.StatusCode())
 	}
 
 	if response.Status != "success" {
-		return "", fmt.Errorf("API returned error status: %s", response.Status)
+		return "", errs.Errorf(errs.UpstreamUnavailable, "API returned error status: %s", response.Status)
 	}
 
-	slog.Info("Successfully retrieved random dog image", "breed", breed)
+	slog.InfoContext(ctx, "Successfully retrieved random dog image", "breed", breed)
 	return response.Message, nil
 }
 
-// DownloadDogImage downloads the actual image from the given URL and returns it as bytes
-func (d *DogAPI) DownloadDogImage(imageURL string) ([]byte, error) {
+// GetRandomDogImagesByBreed gets up to n random dog images for a specific breed
+func (d *DogAPI) GetRandomDogImagesByBreed(ctx context.Context, breed, subBreed string, n int) ([]string, error) {
+	var response dogs.DogImagesResponse
+
 	res, err := d.rClient.R().
+		SetContext(ctx).
+		SetPathParam("breed", breed).
+		SetPathParam("subBreed", subBreed).
+		SetPathParam("n", strconv.Itoa(n)).
+		SetResult(&response).
+		Get(breedPath(subBreed) + "/images/random/{n}")
+	if err != nil {
+		return nil, errs.Errorf(errs.UpstreamUnavailable, "failed to send request: %w", err)
+	}
+
+	if res.StatusCode() == http.StatusNotFound {
+		return nil, dogs.ErrBreedNotFound
+	}
+
+	if res.IsError() {
+		slog.ErrorContext(ctx, "Failed to get random dog images", "breed", breed, "count", n, "status", res.StatusCode())
+		return nil, errs.Errorf(errs.UpstreamUnavailable, "received non-200 response status: %d", res.StatusCode())
+	}
+
+	if response.Status != "success" {
+		return nil, errs.Errorf(errs.UpstreamUnavailable, "API returned error status: %s", response.Status)
+	}
+
+	slog.InfoContext(ctx, "Successfully retrieved random dog images", "breed", breed, "count", len(response.Message))
+	return response.Message, nil
+}
+
+// DownloadDogImage opens the image at the given URL and returns its body as a stream with
+// its size, -1 when the upstream does not send it. The caller must close the body.
+func (d *DogAPI) DownloadDogImage(ctx context.Context, imageURL string) (io.ReadCloser, int64, error) {
+	res, err := d.rClient.R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
 		Get(imageURL)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to download image")
+		return nil, 0, errs.Errorf(errs.UpstreamUnavailable, "failed to download image: %w", err)
 	}
 
 	if res.IsError() {
-		slog.Error("Failed to download image", "url", imageURL, "status", res.StatusCode())
-		return nil, fmt.Errorf("received non-200 response status: %d", res.StatusCode())
+		res.Body.Close()
+		slog.ErrorContext(ctx, "Failed to download image", "url", imageURL, "status", res.StatusCode())
+		return nil, 0, errs.Errorf(errs.UpstreamUnavailable, "received non-200 response status: %d", res.StatusCode())
 	}
 
-	imageBytes := res.Bytes()
-	slog.Info("Successfully downloaded image", "url", imageURL, "size_bytes", len(imageBytes))
+	size := res.RawResponse.ContentLength
+	slog.InfoContext(ctx, "Started image download", "url", imageURL, "size_bytes", size)
+
+	return res.Body, size, nil
+}
+
+// ListAllBreeds gets all breeds mapped to their sub-breeds
+func (d *DogAPI) ListAllBreeds(ctx context.Context) (map[string][]string, error) {
+	var response breeds.BreedsListResponse
 
-	return imageBytes, nil
+	res, err := d.rClient.R().
+		SetContext(ctx).
+		SetResult(&response).
+		Get("/breeds/list/all")
+	if err != nil {
+		return nil, errs.Errorf(errs.UpstreamUnavailable, "failed to send request: %w", err)
+	}
+
+	if res.IsError() {
+		slog.ErrorContext(ctx, "Failed to list breeds", "status", res.StatusCode())
+		return nil, errs.Errorf(errs.UpstreamUnavailable, "received non-200 response status: %d", res.StatusCode())
+	}
+
+	if response.Status != "success" {
+		return nil, errs.Errorf(errs.UpstreamUnavailable, "API returned error status: %s", response.Status)
+	}
+
+	slog.InfoContext(ctx, "Successfully retrieved breeds", "count", len(response.Message))
+	return response.Message, nil
 }
@@ -3,67 +3,384 @@ package s3
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
+	"go-platform/internal/models/objects"
+	"go-platform/pkg/config"
+	"go-platform/pkg/utils/errs"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/google/uuid"
+)
+
+const (
+	// sniffLen is the number of leading bytes used to detect the content type
+	sniffLen = 512
+	// stagingPrefix holds content-addressed uploads until their hash is known
+	stagingPrefix = ".staging"
 )
 
 type clientS3 struct {
-	client             *s3.Client
-	bucketName         string
-	baseEndpoint       string
-	basePublicEndpoint string
+	client        *s3.Client
+	presign       *s3.PresignClient
+	uploader      *manager.Uploader
+	bucketName    string
+	getExpiry     time.Duration
+	putExpiry     time.Duration
+	partSize      int64
+	maxObjectSize int64
 }
 
-// NewClientS3 создает новый экземпляр клиента
-func NewClientS3(keyID, keySecret, bucketName, baseEndpoint, basePublicEndpoint, region string) (*clientS3, error) {
+// NewClientS3 создает новый экземпляр клиента.
+// Presigned URLs are signed for the public endpoint so the bucket can stay private.
+func NewClientS3(cfg config.S3) (*clientS3, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*15)
 	defer cancel()
 
-	cfg, err := config.LoadDefaultConfig(
+	awsCfg, err := awsconfig.LoadDefaultConfig(
 		ctx,
-		config.WithCredentialsProvider(
-			credentials.NewStaticCredentialsProvider(keyID, keySecret, ""),
+		awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.KeyID, cfg.KeySecret, ""),
 		),
-		config.WithRegion(region),
+		awsconfig.WithRegion(cfg.Region),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
-		o.BaseEndpoint = aws.String(baseEndpoint)
+	// streamed bodies cannot be rewound to compute checksums ahead of the request,
+	// checksums are only sent where S3 requires them
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		o.BaseEndpoint = aws.String(cfg.BaseEndpoint)
 		o.UsePathStyle = true
+		o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+		o.APIOptions = append(o.APIOptions, addTracing)
+	})
+
+	// the host is part of the signature, so URLs handed out must be signed for the public endpoint
+	publicClient := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		o.BaseEndpoint = aws.String(cfg.BasePublicEndpoint)
+		o.UsePathStyle = true
+	})
+
+	// only PartSize * Concurrency bytes of an upload are buffered at a time,
+	// parts of a failed upload are aborted
+	uploader := manager.NewUploader(client, func(u *manager.Uploader) {
+		u.PartSize = max(cfg.UploadPartSize, manager.MinUploadPartSize)
+		u.Concurrency = max(cfg.UploadConcurrency, 1)
+		u.LeavePartsOnError = false
 	})
 
 	return &clientS3{
-		client:             client,
-		bucketName:         bucketName,
-		baseEndpoint:       baseEndpoint,
-		basePublicEndpoint: basePublicEndpoint,
+		client:        client,
+		presign:       s3.NewPresignClient(publicClient),
+		uploader:      uploader,
+		bucketName:    cfg.Bucket,
+		getExpiry:     cfg.PresignGetExpiry,
+		putExpiry:     cfg.PresignPutExpiry,
+		partSize:      uploader.PartSize,
+		maxObjectSize: cfg.MaxObjectSize,
 	}, nil
 }
 
-// PutObject — загрузить объект в бакет
-func (c *clientS3) PutObject(ctx context.Context, key string, data []byte) (err error) {
+// PutObject streams body to key. size may be -1 when unknown.
+// Objects of known size up to one part are sent in a single request without buffering,
+// larger or unsized ones go through a multipart upload. Bodies above the configured
+// maximum fail with objects.ErrObjectTooLarge.
+// Content-Type is sniffed from the first bytes and metadata is stored as user metadata of the object.
+func (c *clientS3) PutObject(ctx context.Context, key string, body io.Reader, size int64, metadata map[string]string) error {
+	if c.maxObjectSize > 0 && size > c.maxObjectSize {
+		return fmt.Errorf("failed to put object %s of %d bytes: %w", key, size, objects.ErrObjectTooLarge)
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(body, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to read object body: %w", err)
+	}
+	head = head[:n]
+
+	body = io.MultiReader(bytes.NewReader(head), body)
+	if c.maxObjectSize > 0 {
+		body = &cappedReader{r: body, remaining: c.maxObjectSize}
+	}
+
 	input := &s3.PutObjectInput{
+		Bucket:      &c.bucketName,
+		Key:         &key,
+		Body:        body,
+		ContentType: aws.String(http.DetectContentType(head)),
+		Metadata:    metadata,
+	}
+
+	if size >= 0 && size <= c.partSize {
+		input.ContentLength = aws.Int64(size)
+		// the payload hash cannot be computed ahead of a stream
+		_, err = c.client.PutObject(ctx, input, s3.WithAPIOptions(v4.SwapComputePayloadSHA256ForUnsignedPayloadMiddleware))
+	} else {
+		_, err = c.uploader.Upload(ctx, input)
+	}
+	if err != nil {
+		var failure manager.MultiUploadFailure
+		if errors.As(err, &failure) {
+			slog.WarnContext(ctx, "Multipart upload aborted", "key", key, "upload_id", failure.UploadID())
+		}
+		return errs.Errorf(errs.UpstreamUnavailable, "failed to put object %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// PutContentAddressed streams body under prefix/{sha256 of the bytes}. The body is uploaded to
+// a staging key while it is hashed and then copied to its final key, unless an identical
+// object is already stored. The staging object is always removed.
+// Writing a content-addressed key twice is harmless, so when the existence check fails the copy is made anyway.
+func (c *clientS3) PutContentAddressed(ctx context.Context, prefix string, body io.Reader, size int64, metadata map[string]string) (*objects.ObjectInfo, error) {
+	stagingKey := stagingPrefix + "/" + uuid.New().String()
+
+	hasher := sha256.New()
+	counter := &countingWriter{}
+	if err := c.PutObject(ctx, stagingKey, io.TeeReader(body, io.MultiWriter(hasher, counter)), size, metadata); err != nil {
+		return nil, err
+	}
+	defer func() {
+		// cleanup must run even when the request is cancelled
+		if err := c.DeleteObject(context.WithoutCancel(ctx), stagingKey); err != nil {
+			slog.WarnContext(ctx, "Failed to delete staging object", "key", stagingKey, "error", err)
+		}
+	}()
+
+	hash := hex.EncodeToString(hasher.Sum(nil))
+	key := prefix + "/" + hash
+
+	_, err := c.HeadObject(ctx, key)
+	switch {
+	case err == nil:
+		slog.InfoContext(ctx, "Identical object already stored", "key", key)
+	case errors.Is(err, objects.ErrObjectNotFound):
+		if err := c.CopyObject(ctx, stagingKey, key); err != nil {
+			return nil, err
+		}
+	default:
+		slog.WarnContext(ctx, "Failed to check object, copying anyway", "key", key, "error", err)
+		if err := c.CopyObject(ctx, stagingKey, key); err != nil {
+			return nil, err
+		}
+	}
+
+	return &objects.ObjectInfo{
+		Key:         key,
+		Size:        counter.n,
+		ContentHash: hash,
+	}, nil
+}
+
+// GetObject streams the object body, the caller must close it
+func (c *clientS3) GetObject(ctx context.Context, key string) (io.ReadCloser, *objects.ObjectInfo, error) {
+	out, err := c.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &c.bucketName,
+		Key:    &key,
+	})
+	if err != nil {
+		return nil, nil, c.wrapError("get", key, err)
+	}
+
+	return out.Body, &objects.ObjectInfo{
+		Key:          key,
+		Size:         aws.ToInt64(out.ContentLength),
+		ContentType:  aws.ToString(out.ContentType),
+		ETag:         aws.ToString(out.ETag),
+		LastModified: aws.ToTime(out.LastModified),
+		Metadata:     out.Metadata,
+	}, nil
+}
+
+// HeadObject returns the object attributes without its body
+func (c *clientS3) HeadObject(ctx context.Context, key string) (*objects.ObjectInfo, error) {
+	out, err := c.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: &c.bucketName,
+		Key:    &key,
+	})
+	if err != nil {
+		return nil, c.wrapError("head", key, err)
+	}
+
+	return &objects.ObjectInfo{
+		Key:          key,
+		Size:         aws.ToInt64(out.ContentLength),
+		ContentType:  aws.ToString(out.ContentType),
+		ETag:         aws.ToString(out.ETag),
+		LastModified: aws.ToTime(out.LastModified),
+		Metadata:     out.Metadata,
+	}, nil
+}
+
+// DeleteObject removes the object, deleting a missing key is not an error
+func (c *clientS3) DeleteObject(ctx context.Context, key string) error {
+	_, err := c.client.DeleteObject(ctx, &s3.DeleteObjectInput{
 		Bucket: &c.bucketName,
 		Key:    &key,
-		Body:   bytes.NewReader(data),
+	})
+	if err != nil {
+		return c.wrapError("delete", key, err)
+	}
+
+	return nil
+}
+
+// ListObjects returns up to limit objects under prefix starting at token,
+// pass the returned NextToken to get the next page
+func (c *clientS3) ListObjects(ctx context.Context, prefix, token string, limit int) (*objects.ObjectsPage, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket: &c.bucketName,
+		Prefix: aws.String(prefix),
+	}
+	if token != "" {
+		input.ContinuationToken = aws.String(token)
+	}
+	if limit > 0 {
+		input.MaxKeys = aws.Int32(int32(limit))
+	}
+
+	out, err := c.client.ListObjectsV2(ctx, input)
+	if err != nil {
+		return nil, c.wrapError("list", prefix, err)
+	}
+
+	page := &objects.ObjectsPage{Objects: make([]objects.ObjectInfo, 0, len(out.Contents))}
+	for _, obj := range out.Contents {
+		page.Objects = append(page.Objects, objects.ObjectInfo{
+			Key:          aws.ToString(obj.Key),
+			Size:         aws.ToInt64(obj.Size),
+			ETag:         aws.ToString(obj.ETag),
+			LastModified: aws.ToTime(obj.LastModified),
+		})
+	}
+	if aws.ToBool(out.IsTruncated) {
+		page.NextToken = aws.ToString(out.NextContinuationToken)
+	}
+
+	return page, nil
+}
+
+// CopyObject copies srcKey to dstKey within the bucket keeping content type and metadata
+func (c *clientS3) CopyObject(ctx context.Context, srcKey, dstKey string) error {
+	_, err := c.client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     &c.bucketName,
+		Key:        &dstKey,
+		CopySource: aws.String(url.PathEscape(c.bucketName) + "/" + escapeKey(srcKey)),
+	})
+	if err != nil {
+		return c.wrapError("copy", srcKey, err)
+	}
+
+	return nil
+}
+
+// GenerateURL generates a presigned GET URL for the given key
+func (c *clientS3) GenerateURL(ctx context.Context, key string) (string, error) {
+	req, err := c.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: &c.bucketName,
+		Key:    &key,
+	}, s3.WithPresignExpires(c.getExpiry))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign get object %s: %w", key, err)
 	}
-	_, err = c.client.PutObject(ctx, input)
+
+	return req.URL, nil
+}
+
+// PresignPutURL generates a presigned PUT request uploading an object of contentType to key
+func (c *clientS3) PresignPutURL(ctx context.Context, key, contentType string) (*objects.PresignedRequest, error) {
+	expiresAt := time.Now().Add(c.putExpiry)
+
+	req, err := c.presign.PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:      &c.bucketName,
+		Key:         &key,
+		ContentType: aws.String(contentType),
+	}, s3.WithPresignExpires(c.putExpiry))
 	if err != nil {
-		return
+		return nil, fmt.Errorf("failed to presign put object %s: %w", key, err)
 	}
 
-	return
+	headers := make(map[string]string, len(req.SignedHeader))
+	for name, values := range req.SignedHeader {
+		// host is set by the HTTP client from the URL
+		if strings.EqualFold(name, "Host") || len(values) == 0 {
+			continue
+		}
+		headers[name] = values[0]
+	}
+
+	return &objects.PresignedRequest{
+		URL:       req.URL,
+		Method:    req.Method,
+		Headers:   headers,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// wrapError maps missing keys to objects.ErrObjectNotFound, any other failure means S3 is unavailable
+func (c *clientS3) wrapError(op, key string, err error) error {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	if errors.As(err, &noSuchKey) || errors.As(err, &notFound) {
+		return fmt.Errorf("failed to %s object %s: %w", op, key, objects.ErrObjectNotFound)
+	}
+	return errs.Errorf(errs.UpstreamUnavailable, "failed to %s object %s: %w", op, key, err)
 }
 
-// GenerateURL generates a public URL for the given key
-func (c *clientS3) GenerateURL(key string) string {
-	return strings.Join([]string{c.basePublicEndpoint, c.bucketName, key}, "/")
+// cappedReader fails with objects.ErrObjectTooLarge once more than remaining bytes are read
+type cappedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (r *cappedReader) Read(p []byte) (int, error) {
+	if r.remaining < 0 {
+		return 0, objects.ErrObjectTooLarge
+	}
+	// read one byte past the limit to tell an exact fit from an overflow
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.r.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, objects.ErrObjectTooLarge
+	}
+	return n, err
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// escapeKey escapes every path segment of the key keeping the separators
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
 }
@@ -0,0 +1,238 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-platform/internal/models/objects"
+	"go-platform/pkg/config"
+)
+
+const testBucket = "dogs"
+
+type storedObject struct {
+	data        []byte
+	contentType string
+	metadata    http.Header
+}
+
+// fakeBucket serves the path-style S3 calls made by the client for a single bucket.
+// Signatures are not checked.
+type fakeBucket struct {
+	mu      sync.Mutex
+	objects map[string]storedObject
+}
+
+func (b *fakeBucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/"+testBucket), "/")
+	switch {
+	case r.Method == http.MethodGet && key == "":
+		b.list(w, r)
+	case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+		source, _ := url.PathUnescape(r.Header.Get("X-Amz-Copy-Source"))
+		obj, ok := b.objects[strings.TrimPrefix(strings.TrimPrefix(source, "/"), testBucket+"/")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		b.objects[key] = obj
+		fmt.Fprintf(w, `<CopyObjectResult><ETag>"etag"</ETag><LastModified>%s</LastModified></CopyObjectResult>`, time.Now().UTC().Format(time.RFC3339))
+	case r.Method == http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		metadata := http.Header{}
+		for name, values := range r.Header {
+			if strings.HasPrefix(strings.ToLower(name), "x-amz-meta-") {
+				metadata[name] = values
+			}
+		}
+		b.objects[key] = storedObject{data: data, contentType: r.Header.Get("Content-Type"), metadata: metadata}
+		w.Header().Set("ETag", `"etag"`)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		obj, ok := b.objects[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		for name, values := range obj.metadata {
+			w.Header()[name] = values
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.data)))
+		w.Header().Set("ETag", `"etag"`)
+		if r.Method == http.MethodGet {
+			w.Write(obj.data)
+		}
+	case r.Method == http.MethodDelete:
+		delete(b.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+type listResult struct {
+	XMLName               xml.Name `xml:"ListBucketResult"`
+	IsTruncated           bool
+	NextContinuationToken string `xml:",omitempty"`
+	Contents              []listEntry
+}
+
+type listEntry struct {
+	Key  string
+	Size int
+}
+
+// list pages keys in order, the continuation token is the last key of the previous page
+func (b *fakeBucket) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, _ := strconv.Atoi(query.Get("max-keys"))
+
+	var keys []string
+	for key := range b.objects {
+		if strings.HasPrefix(key, query.Get("prefix")) && key > query.Get("continuation-token") {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	var result listResult
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+		result.IsTruncated = true
+		result.NextContinuationToken = keys[limit-1]
+	}
+	for _, key := range keys {
+		result.Contents = append(result.Contents, listEntry{Key: key, Size: len(b.objects[key].data)})
+	}
+	xml.NewEncoder(w).Encode(result)
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<Error><Code>%s</Code></Error>`, code)
+}
+
+func newTestClient(t *testing.T) *clientS3 {
+	t.Helper()
+	server := httptest.NewServer(&fakeBucket{objects: make(map[string]storedObject)})
+	t.Cleanup(server.Close)
+
+	client, err := NewClientS3(config.S3{
+		KeyID:              "key",
+		KeySecret:          "secret",
+		Bucket:             testBucket,
+		BaseEndpoint:       server.URL,
+		BasePublicEndpoint: server.URL,
+		Region:             "us-east-1",
+		UploadPartSize:     5 << 20,
+		UploadConcurrency:  1,
+		MaxObjectSize:      1 << 20,
+	})
+	if err != nil {
+		t.Fatalf("NewClientS3() error = %v", err)
+	}
+	return client
+}
+
+func TestObjectLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	png := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 64)...)
+
+	err := client.PutObject(ctx, "dogs/hound/1", bytes.NewReader(png), int64(len(png)), map[string]string{objects.MetadataBreed: "hound"})
+	if err != nil {
+		t.Fatalf("PutObject() error = %v", err)
+	}
+
+	info, err := client.HeadObject(ctx, "dogs/hound/1")
+	if err != nil {
+		t.Fatalf("HeadObject() error = %v", err)
+	}
+	if info.Size != int64(len(png)) || info.ContentType != "image/png" || info.Metadata[objects.MetadataBreed] != "hound" {
+		t.Fatalf("HeadObject() = %+v, want %d bytes of image/png with breed hound", info, len(png))
+	}
+
+	if err := client.CopyObject(ctx, "dogs/hound/1", "dogs/hound/2"); err != nil {
+		t.Fatalf("CopyObject() error = %v", err)
+	}
+	body, _, err := client.GetObject(ctx, "dogs/hound/2")
+	if err != nil {
+		t.Fatalf("GetObject() error = %v", err)
+	}
+	data, err := io.ReadAll(body)
+	body.Close()
+	if err != nil || !bytes.Equal(data, png) {
+		t.Fatalf("GetObject() read %d bytes, %v, want the copied %d bytes", len(data), err, len(png))
+	}
+
+	if err := client.DeleteObject(ctx, "dogs/hound/1"); err != nil {
+		t.Fatalf("DeleteObject() error = %v", err)
+	}
+	if _, err := client.HeadObject(ctx, "dogs/hound/1"); !errors.Is(err, objects.ErrObjectNotFound) {
+		t.Fatalf("HeadObject() of a deleted key error = %v, want %v", err, objects.ErrObjectNotFound)
+	}
+	if _, _, err := client.GetObject(ctx, "dogs/hound/1"); !errors.Is(err, objects.ErrObjectNotFound) {
+		t.Fatalf("GetObject() of a deleted key error = %v, want %v", err, objects.ErrObjectNotFound)
+	}
+}
+
+func TestListObjectsPages(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	for _, key := range []string{"dogs/hound/1", "dogs/hound/2", "dogs/hound/3", "dogs/pug/1"} {
+		if err := client.PutObject(ctx, key, strings.NewReader(key), int64(len(key)), nil); err != nil {
+			t.Fatalf("PutObject(%s) error = %v", key, err)
+		}
+	}
+
+	var (
+		keys  []string
+		token string
+		pages int
+	)
+	for {
+		page, err := client.ListObjects(ctx, "dogs/hound/", token, 2)
+		if err != nil {
+			t.Fatalf("ListObjects() error = %v", err)
+		}
+		pages++
+		for _, obj := range page.Objects {
+			keys = append(keys, obj.Key)
+		}
+		if page.NextToken == "" {
+			break
+		}
+		token = page.NextToken
+	}
+
+	want := []string{"dogs/hound/1", "dogs/hound/2", "dogs/hound/3"}
+	if pages != 2 || !slices.Equal(keys, want) {
+		t.Fatalf("ListObjects() listed %v in %d pages, want %v in 2", keys, pages, want)
+	}
+}
+
+func TestPutObjectTooLarge(t *testing.T) {
+	client := newTestClient(t)
+
+	err := client.PutObject(context.Background(), "dogs/hound/huge", bytes.NewReader(nil), 2<<20, nil)
+	if !errors.Is(err, objects.ErrObjectTooLarge) {
+		t.Fatalf("PutObject() error = %v, want %v", err, objects.ErrObjectTooLarge)
+	}
+}
@@ -0,0 +1,82 @@
+package s3
+
+import (
+	"context"
+	"errors"
+
+	"go-platform/pkg/tracer"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// addTracing records every S3 call, including the parts of multipart uploads, as a client span
+func addTracing(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("Tracing", traceOperation), middleware.After)
+}
+
+func traceOperation(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+	service, operation := awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx)
+
+	attrs := []attribute.KeyValue{
+		semconv.RPCSystemKey.String("aws-api"),
+		semconv.RPCService(service),
+		semconv.RPCMethod(operation),
+	}
+	if bucket, key := objectOf(in.Parameters); bucket != "" {
+		attrs = append(attrs, attribute.String("aws.s3.bucket", bucket), attribute.String("aws.s3.key", key))
+	}
+
+	ctx, span := tracer.StartSpan(ctx, service+"."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+
+	out, metadata, err := next.HandleInitialize(ctx, in)
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) {
+		span.SetAttributes(semconv.HTTPStatusCode(respErr.HTTPStatusCode()))
+	}
+	tracer.EndSpan(span, err)
+
+	return out, metadata, err
+}
+
+// objectOf returns the bucket and key of the object calls made by the client
+func objectOf(params any) (string, string) {
+	var bucket, key *string
+	switch in := params.(type) {
+	case *s3.PutObjectInput:
+		bucket, key = in.Bucket, in.Key
+	case *s3.GetObjectInput:
+		bucket, key = in.Bucket, in.Key
+	case *s3.HeadObjectInput:
+		bucket, key = in.Bucket, in.Key
+	case *s3.DeleteObjectInput:
+		bucket, key = in.Bucket, in.Key
+	case *s3.CopyObjectInput:
+		bucket, key = in.Bucket, in.Key
+	case *s3.CreateMultipartUploadInput:
+		bucket, key = in.Bucket, in.Key
+	case *s3.UploadPartInput:
+		bucket, key = in.Bucket, in.Key
+	case *s3.CompleteMultipartUploadInput:
+		bucket, key = in.Bucket, in.Key
+	case *s3.AbortMultipartUploadInput:
+		bucket, key = in.Bucket, in.Key
+	case *s3.ListObjectsV2Input:
+		bucket, key = in.Bucket, in.Prefix
+	}
+	if bucket == nil {
+		return "", ""
+	}
+	if key == nil {
+		return *bucket, ""
+	}
+	return *bucket, *key
+}
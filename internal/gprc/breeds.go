@@ -0,0 +1,25 @@
+package grpc
+
+import (
+	"context"
+	proto "go-platform/api/protobuf"
+)
+
+func (s *server) ListBreeds(ctx context.Context, _ *proto.ListBreedsRequest) (*proto.ListBreedsResponse, error) {
+	catalog, err := s.breedsService.ListBreeds(ctx)
+	if err != nil {
+		return nil, statusError(ctx, "Failed to list breeds", err)
+	}
+
+	resp := &proto.ListBreedsResponse{
+		Breeds: make([]*proto.Breed, 0, len(catalog)),
+	}
+	for _, breed := range catalog {
+		resp.Breeds = append(resp.Breeds, &proto.Breed{
+			Name:      breed.Name,
+			SubBreeds: breed.SubBreeds,
+		})
+	}
+
+	return resp, nil
+}
@@ -3,27 +3,69 @@ package grpc
 import (
 	"context"
 	proto "go-platform/api/protobuf"
+	models "go-platform/internal/models/dogs"
 	"log/slog"
-	"time"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *server) GetRandomDogImage(ctx context.Context, req *proto.GetRandomDogImageRequest) (*proto.GetRandomDogImageResponse, error) {
-	breed := req.GetBreed()
+	breed, subBreed := req.GetBreed(), req.GetSubBreed()
 
-	imageURL, err := s.dogsService.GetRandomDogImage(ctx, breed)
+	dog, err := s.dogsService.GetRandomDogImage(ctx, breed, subBreed)
 	if err != nil {
-		slog.Error("Service failed", "breed", breed, "error", err)
-		return nil, status.Errorf(codes.Internal, "Failed to get dog image")
+		return nil, statusError(ctx, "Failed to get dog image", err, "breed", breed, "sub_breed", subBreed)
 	}
-	slog.Info("Service completed", "breed", breed, "image_url", imageURL)
+	slog.InfoContext(ctx, "Service completed", "breed", breed, "image_url", dog.ImageURL)
 
 	return &proto.GetRandomDogImageResponse{
-		ImageUrl:  imageURL,
-		Breed:     breed,
-		CreatedAt: timestamppb.New(time.Now()),
+		ImageUrl:     dog.ImageURL,
+		Breed:        breed,
+		SubBreed:     subBreed,
+		CreatedAt:    timestamppb.New(dog.CreatedAt),
+		ThumbnailUrl: dog.ThumbnailURL,
+		MediumUrl:    dog.MediumURL,
+		Width:        int32(dog.Width),
+		Height:       int32(dog.Height),
+		MimeType:     dog.MIMEType,
 	}, nil
 }
+
+// GetRandomDogImages ingests several images of a breed or sub-breed and streams each result as soon as it completes
+func (s *server) GetRandomDogImages(req *proto.GetRandomDogImagesRequest, stream grpc.ServerStreamingServer[proto.DogImageResult]) error {
+	breed, subBreed := req.GetBreed(), req.GetSubBreed()
+	if breed == "" {
+		return status.Errorf(codes.InvalidArgument, "breed is required")
+	}
+	if req.GetCount() <= 0 {
+		return status.Errorf(codes.InvalidArgument, "count must be positive")
+	}
+
+	// results are delivered one at a time, the first failed send is kept and later sends are skipped
+	var sendErr error
+	resp, err := s.dogsService.GetRandomDogImages(stream.Context(), breed, subBreed, int(req.GetCount()), func(result models.BatchImageResult) {
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(&proto.DogImageResult{
+			Index:        int32(result.Index),
+			SourceUrl:    result.SourceURL,
+			ImageUrl:     result.ImageURL,
+			Error:        result.Error,
+			ThumbnailUrl: result.ThumbnailURL,
+			MediumUrl:    result.MediumURL,
+		})
+	})
+	if err != nil {
+		return statusError(stream.Context(), "Failed to get dog images", err, "breed", breed, "sub_breed", subBreed)
+	}
+	if sendErr != nil {
+		return sendErr
+	}
+	slog.InfoContext(stream.Context(), "Service completed", "breed", breed, "succeeded", resp.Succeeded, "failed", resp.Failed)
+
+	return nil
+}
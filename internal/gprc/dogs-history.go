@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"context"
+	proto "go-platform/api/protobuf"
+	models "go-platform/internal/models/dogs"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *server) GetDog(ctx context.Context, req *proto.GetDogRequest) (*proto.Dog, error) {
+	id := req.GetId()
+
+	dog, err := s.dogsService.GetDog(ctx, id)
+	if err != nil {
+		return nil, statusError(ctx, "Failed to get dog", err, "id", id)
+	}
+
+	return toProtoDog(dog), nil
+}
+
+func (s *server) ListDogs(ctx context.Context, req *proto.ListDogsRequest) (*proto.ListDogsResponse, error) {
+	filter := models.ListDogsFilter{
+		Breed:  req.GetBreed(),
+		Cursor: req.GetCursor(),
+		Limit:  int(req.GetLimit()),
+	}
+	if req.GetFrom() != nil {
+		filter.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		filter.To = req.GetTo().AsTime()
+	}
+
+	page, err := s.dogsService.ListDogs(ctx, filter)
+	if err != nil {
+		return nil, statusError(ctx, "Failed to list dogs", err)
+	}
+
+	resp := &proto.ListDogsResponse{
+		Dogs:       make([]*proto.Dog, 0, len(page.Dogs)),
+		NextCursor: page.NextCursor,
+	}
+	for i := range page.Dogs {
+		resp.Dogs = append(resp.Dogs, toProtoDog(&page.Dogs[i]))
+	}
+
+	return resp, nil
+}
+
+func toProtoDog(dog *models.Dog) *proto.Dog {
+	return &proto.Dog{
+		Id:           dog.ID,
+		ImageUrl:     dog.ImageURL,
+		Breed:        dog.Breed,
+		SubBreed:     dog.SubBreed,
+		CreatedAt:    timestamppb.New(dog.CreatedAt),
+		ThumbnailUrl: dog.ThumbnailURL,
+		MediumUrl:    dog.MediumURL,
+		Width:        int32(dog.Width),
+		Height:       int32(dog.Height),
+		MimeType:     dog.MIMEType,
+	}
+}
@@ -0,0 +1,59 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+
+	"go-platform/pkg/utils/errs"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain is the ErrorInfo domain of errors returned by the service
+const errorDomain = "go-platform"
+
+// kindCodes is the gRPC code of every error kind, errors without a kind are Internal
+var kindCodes = map[errs.Kind]codes.Code{
+	errs.NotFound:            codes.NotFound,
+	errs.InvalidArgument:     codes.InvalidArgument,
+	errs.Conflict:            codes.Aborted,
+	errs.Unavailable:         codes.Unavailable,
+	errs.UpstreamUnavailable: codes.Unavailable,
+	errs.Timeout:             codes.DeadlineExceeded,
+	errs.Canceled:            codes.Canceled,
+}
+
+// statusError converts a failed service call to a gRPC status with the code of the error kind
+// and an ErrorInfo detail naming the kind. The message of an error without a kind is not
+// exposed, message is sent instead. Logging follows the HTTP handlers.
+func statusError(ctx context.Context, message string, err error, args ...any) error {
+	kind := errs.KindOf(err)
+	args = append(args, "kind", kind.String(), "error", err)
+
+	code, ok := kindCodes[kind]
+	switch {
+	case !ok:
+		slog.ErrorContext(ctx, message, args...)
+		code = codes.Internal
+	case kind == errs.Canceled || kind == errs.Timeout:
+		slog.WarnContext(ctx, "Request cancelled", args...)
+		message = err.Error()
+	case kind == errs.NotFound || kind == errs.InvalidArgument || kind == errs.Conflict:
+		slog.InfoContext(ctx, message, args...)
+		message = err.Error()
+	default:
+		slog.ErrorContext(ctx, message, args...)
+		message = err.Error()
+	}
+
+	st, detailErr := status.New(code, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: kind.String(),
+		Domain: errorDomain,
+	})
+	if detailErr != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"go-platform/pkg/utils/errs"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantMessage string
+		wantReason  string
+	}{
+		{name: "not found", err: errs.New(errs.NotFound, "dog not found"), wantCode: codes.NotFound, wantMessage: "dog not found", wantReason: "NOT_FOUND"},
+		{name: "invalid argument", err: errs.New(errs.InvalidArgument, "invalid cursor"), wantCode: codes.InvalidArgument, wantMessage: "invalid cursor", wantReason: "INVALID_ARGUMENT"},
+		{name: "conflict", err: errs.New(errs.Conflict, "changed"), wantCode: codes.Aborted, wantMessage: "changed", wantReason: "CONFLICT"},
+		{name: "unavailable", err: errs.New(errs.Unavailable, "queue full"), wantCode: codes.Unavailable, wantMessage: "queue full", wantReason: "UNAVAILABLE"},
+		{name: "upstream unavailable", err: errs.New(errs.UpstreamUnavailable, "dog API down"), wantCode: codes.Unavailable, wantMessage: "dog API down", wantReason: "UPSTREAM_UNAVAILABLE"},
+		{name: "timeout", err: fmt.Errorf("call: %w", context.DeadlineExceeded), wantCode: codes.DeadlineExceeded, wantMessage: "call: context deadline exceeded", wantReason: "TIMEOUT"},
+		{name: "canceled", err: context.Canceled, wantCode: codes.Canceled, wantMessage: "context canceled", wantReason: "CANCELED"},
+		{name: "no kind hides the error", err: errors.New("pq: password authentication failed"), wantCode: codes.Internal, wantMessage: "Failed to get dog", wantReason: "UNKNOWN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(statusError(context.Background(), "Failed to get dog", tt.err))
+			if st.Code() != tt.wantCode || st.Message() != tt.wantMessage {
+				t.Fatalf("statusError() = %v %q, want %v %q", st.Code(), st.Message(), tt.wantCode, tt.wantMessage)
+			}
+
+			details := st.Details()
+			if len(details) != 1 {
+				t.Fatalf("statusError() has %d details, want 1", len(details))
+			}
+			info, ok := details[0].(*errdetails.ErrorInfo)
+			if !ok || info.Reason != tt.wantReason || info.Domain != errorDomain {
+				t.Fatalf("statusError() detail = %v, want reason %s in %s", details[0], tt.wantReason, errorDomain)
+			}
+		})
+	}
+}
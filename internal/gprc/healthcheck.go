@@ -7,7 +7,7 @@ import (
 )
 
 func (s *server) Check(ctx context.Context, req *proto.HealthCheckRequest) (*proto.HealthCheckResponse, error) {
-	slog.Info("GRPC handler for HealthCheckRequest started", "request", req)
+	slog.InfoContext(ctx, "GRPC handler for HealthCheckRequest started", "request", req)
 	return &proto.HealthCheckResponse{
 		Status: proto.HealthCheckResponse_SERVING,
 	}, nil
@@ -5,21 +5,102 @@ import (
 	"log/slog"
 
 	proto "go-platform/api/protobuf"
+	"go-platform/pkg/requestid"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+// CancelInterceptor cancels the call context once requests is cancelled,
+// so handlers waiting on upstream calls return when the server stops draining
+func CancelInterceptor(requests context.Context) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stop := context.AfterFunc(requests, cancel)
+		defer stop()
+
+		return handler(ctx, req)
+	}
+}
+
+// CancelStreamInterceptor is CancelInterceptor for streaming calls
+func CancelStreamInterceptor(requests context.Context) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := context.WithCancel(ss.Context())
+		defer cancel()
+		stop := context.AfterFunc(requests, cancel)
+		defer stop()
+
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// RequestIDInterceptor accepts the x-request-id metadata of the client or generates one,
+// sends it back in the response header and stores it in the context for logs and outbound calls
+func RequestIDInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	id := incomingRequestID(ctx)
+	if err := grpc.SetHeader(ctx, metadata.Pairs(requestid.MetadataKey, id)); err != nil {
+		slog.WarnContext(ctx, "Failed to set request ID header", "error", err)
+	}
+
+	return handler(requestid.NewContext(ctx, id), req)
+}
+
+// RequestIDStreamInterceptor is RequestIDInterceptor for streaming calls
+func RequestIDStreamInterceptor(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	id := incomingRequestID(ss.Context())
+	if err := ss.SetHeader(metadata.Pairs(requestid.MetadataKey, id)); err != nil {
+		slog.WarnContext(ss.Context(), "Failed to set request ID header", "error", err)
+	}
+
+	return handler(srv, &contextStream{ServerStream: ss, ctx: requestid.NewContext(ss.Context(), id)})
+}
+
+// incomingRequestID resolves the request ID of the call and records it on the call span
+func incomingRequestID(ctx context.Context) string {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestid.MetadataKey); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	id = requestid.Resolve(id)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("request_id", id))
+	return id
+}
+
+// contextStream is a server stream running under a context derived by an interceptor
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
 func ValidationInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 	switch val := req.(type) {
 	case *proto.HealthCheckRequest:
-		slog.Info("Middleware for HealthCheckRequest started", "request", val)
+		slog.InfoContext(ctx, "Middleware for HealthCheckRequest started", "request", val)
 		return handler(ctx, req)
 	case *proto.GetRandomDogImageRequest:
 		if val.GetBreed() == "" {
 			return nil, status.Errorf(codes.InvalidArgument, "breed is required")
 		}
+	case *proto.GetDogRequest:
+		if val.GetId() == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "id is required")
+		}
+	case *proto.ListDogsRequest:
+		if val.GetLimit() < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "limit must not be negative, 0 uses the default page size")
+		}
 	}
 	return handler(ctx, req)
 
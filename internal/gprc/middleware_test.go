@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	proto "go-platform/api/protobuf"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidationInterceptorListDogsLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int32
+		wantCode codes.Code
+	}{
+		{name: "negative", limit: -1, wantCode: codes.InvalidArgument},
+		{name: "unset uses the default", limit: 0, wantCode: codes.OK},
+		{name: "positive", limit: 20, wantCode: codes.OK},
+	}
+
+	handler := func(context.Context, interface{}) (interface{}, error) { return &proto.ListDogsResponse{}, nil }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidationInterceptor(context.Background(), &proto.ListDogsRequest{Limit: tt.limit}, &grpc.UnaryServerInfo{}, handler)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("ValidationInterceptor() code = %v, want %v", got, tt.wantCode)
+			}
+		})
+	}
+}
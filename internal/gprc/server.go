@@ -5,29 +5,53 @@ import (
 	"net"
 
 	proto "go-platform/api/protobuf"
+	"go-platform/internal/models/breeds"
+	"go-platform/internal/models/dogs"
+	"go-platform/pkg/metrics"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
 type DogsService interface {
-	GetRandomDogImage(ctx context.Context, breed string) (string, error)
+	GetRandomDogImage(ctx context.Context, breed, subBreed string) (*dogs.Dog, error)
+	GetRandomDogImages(ctx context.Context, breed, subBreed string, n int, onResult func(dogs.BatchImageResult)) (*dogs.BatchImagesResponse, error)
+	GetDog(ctx context.Context, id string) (*dogs.Dog, error)
+	ListDogs(ctx context.Context, filter dogs.ListDogsFilter) (*dogs.DogsPage, error)
+}
+
+type BreedsService interface {
+	ListBreeds(ctx context.Context) ([]breeds.Breed, error)
 }
 
 type server struct {
-	dogsService DogsService
-	grpcServer  *grpc.Server
+	dogsService   DogsService
+	breedsService BreedsService
+	grpcServer    *grpc.Server
 	proto.UnimplementedHealthServer
 	proto.UnimplementedDogServiceServer
 }
 
-func NewServer(dogsService DogsService) *server {
+// NewServer builds the gRPC server, calls are cancelled together with requests
+func NewServer(requests context.Context, dogsService DogsService, breedsService BreedsService, grpcMetrics *metrics.GRPCMetrics) *server {
 	s := &server{
-		dogsService: dogsService,
-		grpcServer: grpc.NewServer(grpc.ChainUnaryInterceptor(
-			LogInterceptor,
-			ValidationInterceptor,
-		)),
+		dogsService:   dogsService,
+		breedsService: breedsService,
+		grpcServer: grpc.NewServer(
+			grpc.StatsHandler(tracingHandler{}),
+			grpc.ChainUnaryInterceptor(
+				grpcMetrics.UnaryServerInterceptor,
+				CancelInterceptor(requests),
+				RequestIDInterceptor,
+				LogInterceptor,
+				ValidationInterceptor,
+			),
+			grpc.ChainStreamInterceptor(
+				grpcMetrics.StreamServerInterceptor,
+				CancelStreamInterceptor(requests),
+				RequestIDStreamInterceptor,
+			),
+		),
 	}
 
 	proto.RegisterHealthServer(s.grpcServer, s)
@@ -44,3 +68,7 @@ func (s *server) Serve(listener net.Listener) error {
 func (s *server) GracefulStop() {
 	s.grpcServer.GracefulStop()
 }
+
+func (s *server) Stop() {
+	s.grpcServer.Stop()
+}
@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"go-platform/pkg/tracer"
+
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+// serverErrorCodes are the codes that mark a call span as failed, other codes are the fault of the client
+var serverErrorCodes = map[codes.Code]bool{
+	codes.Unknown:          true,
+	codes.DeadlineExceeded: true,
+	codes.Unimplemented:    true,
+	codes.Internal:         true,
+	codes.Unavailable:      true,
+	codes.DataLoss:         true,
+}
+
+// tracingHandler serves every call in a server span continuing the trace sent in the call metadata.
+// It runs before the interceptors, so their logs carry the trace of the call.
+type tracingHandler struct{}
+
+func (tracingHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+	name := strings.TrimPrefix(info.FullMethodName, "/")
+	service, method, _ := strings.Cut(name, "/")
+	ctx, _ = tracer.StartSpan(ctx, name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(service),
+			semconv.RPCMethod(method),
+		),
+	)
+	return ctx
+}
+
+func (tracingHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	end, ok := rs.(*stats.End)
+	if !ok {
+		return
+	}
+
+	span := trace.SpanFromContext(ctx)
+	code := status.Code(end.Error)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+	if serverErrorCodes[code] {
+		span.SetStatus(otelcodes.Error, end.Error.Error())
+	}
+	span.End()
+}
+
+func (tracingHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (tracingHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+// metadataCarrier reads the trace context from incoming call metadata
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	proto "go-platform/api/protobuf"
+	"go-platform/internal/models/dogs"
+	"go-platform/pkg/metrics"
+	"go-platform/pkg/utils/errs"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// unavailableDogs fails every call as if the storage was down
+type unavailableDogs struct {
+	DogsService
+}
+
+func (unavailableDogs) GetDog(context.Context, string) (*dogs.Dog, error) {
+	return nil, errs.New(errs.Unavailable, "storage down")
+}
+
+func TestServerSpansContinueTheClientTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	s := NewServer(context.Background(), unavailableDogs{}, nil, metrics.NewGRPCMetrics(prometheus.NewRegistry()))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer conn.Close()
+	client := proto.NewDogServiceClient(conn)
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	tests := []struct {
+		name       string
+		id         string
+		wantStatus otelcodes.Code
+	}{
+		{name: "client error leaves the span unset", id: "", wantStatus: otelcodes.Unset},
+		{name: "server error marks the span", id: "42", wantStatus: otelcodes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := propagation.MapCarrier{}
+			otel.GetTextMapPropagator().Inject(trace.ContextWithSpanContext(context.Background(), parent), headers)
+			ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(headers))
+
+			ended := len(recorder.Ended())
+			if _, err := client.GetDog(ctx, &proto.GetDogRequest{Id: tt.id}); err == nil {
+				t.Fatal("GetDog() error = nil, want an error")
+			}
+
+			// the server ends the span after the status is sent
+			spans := recorder.Ended()
+			for deadline := time.Now().Add(time.Second); len(spans) == ended && time.Now().Before(deadline); {
+				time.Sleep(time.Millisecond)
+				spans = recorder.Ended()
+			}
+			if len(spans) == ended {
+				t.Fatal("no span recorded")
+			}
+			span := spans[len(spans)-1]
+			if span.Name() != "go_platform.dogs.DogService/GetDog" || span.SpanKind() != trace.SpanKindServer {
+				t.Fatalf("span = %s of kind %v, want the GetDog server span", span.Name(), span.SpanKind())
+			}
+			if span.Parent().TraceID() != parent.TraceID() || span.Parent().SpanID() != parent.SpanID() {
+				t.Fatalf("span parent = %v, want %v", span.Parent(), parent)
+			}
+			if span.Status().Code != tt.wantStatus {
+				t.Fatalf("span status = %v, want %v", span.Status().Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go-platform/internal/models/breeds"
+	httputils "go-platform/pkg/utils/http-utils"
+)
+
+// ListBreeds godoc
+//
+//	@Summary		List breeds
+//	@Description	Returns the breed catalog synced from the dog API with sub-breeds, ordered by name
+//	@Tags			Breeds
+//	@Produce		json
+//	@Success		200	{object}	breeds.BreedsResponse
+//	@Failure		500	{object}	httputils.ErrorResponse
+//	@Router			/api/v1/breeds [get]
+func (h *Handler) ListBreeds(w http.ResponseWriter, r *http.Request) {
+	catalog, err := h.breedsService.ListBreeds(r.Context())
+	if err != nil {
+		writeError(w, r, "Failed to list breeds", err)
+		return
+	}
+
+	response := breeds.BreedsResponse{
+		Breeds: catalog,
+		Count:  len(catalog),
+	}
+	httputils.WriteResponse(w, http.StatusOK, "Breeds retrieved successfully", nil, response)
+}
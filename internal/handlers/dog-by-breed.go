@@ -3,6 +3,8 @@ package handlers
 import (
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"go-platform/internal/models/dogs"
 	httputils "go-platform/pkg/utils/http-utils"
@@ -21,32 +23,169 @@ import (
 //	@Failure		400	{object}	httputils.ErrorResponse
 //	@Failure		404	{object}	httputils.ErrorResponse
 //	@Failure		500	{object}	httputils.ErrorResponse
+//	@Failure		502	{object}	httputils.ErrorResponse
+//	@Failure		504	{object}	httputils.ErrorResponse
 //	@Router			/api/v1/dogs/{breed}/image [get]
 func (h *Handler) GetRandomDogImageByBreed(w http.ResponseWriter, r *http.Request) {
+	h.getRandomDogImage(w, r)
+}
+
+// GetRandomDogImageBySubBreed godoc
+//
+//	@Summary		Get random dog image by sub-breed
+//	@Description	Retrieves a random dog image for the specified sub-breed, downloads it, and uploads to S3
+//	@Tags			Dogs
+//	@Param			breed		path	string	true	"Dog breed"
+//	@Param			subBreed	path	string	true	"Dog sub-breed"
+//	@Produce		json
+//	@Success		200	{object}	dogs.DogImageResponse	"S3 URL of the uploaded image"
+//	@Failure		400	{object}	httputils.ErrorResponse
+//	@Failure		404	{object}	httputils.ErrorResponse
+//	@Failure		500	{object}	httputils.ErrorResponse
+//	@Failure		502	{object}	httputils.ErrorResponse
+//	@Failure		504	{object}	httputils.ErrorResponse
+//	@Router			/api/v1/dogs/{breed}/{subBreed}/image [get]
+func (h *Handler) GetRandomDogImageBySubBreed(w http.ResponseWriter, r *http.Request) {
+	h.getRandomDogImage(w, r)
+}
+
+// getRandomDogImage serves a random image of the breed and, when the route has one, of its sub-breed
+func (h *Handler) getRandomDogImage(w http.ResponseWriter, r *http.Request) {
 
 	// Extract breed from URL path
 	vars := mux.Vars(r)
-	breed := vars["breed"]
+	breed, subBreed := vars["breed"], vars["subBreed"]
 	if breed == "" {
-		slog.Error("Empty breed parameter")
+		slog.ErrorContext(r.Context(), "Empty breed parameter")
 		httputils.WriteResponse(w, http.StatusBadRequest, "Breed parameter is required", nil, nil)
 		return
 	}
-	slog.Info("Processing dog image request", "breed", breed)
+	slog.InfoContext(r.Context(), "Processing dog image request", "breed", breed, "sub_breed", subBreed)
 
 	// Call service layer
-	imageURL, err := h.dogsService.GetRandomDogImage(r.Context(), breed)
+	dog, err := h.dogsService.GetRandomDogImage(r.Context(), breed, subBreed)
 	if err != nil {
-		slog.Error("Service failed", "breed", breed, "error", err)
-		httputils.WriteResponse(w, http.StatusInternalServerError, "Failed to get dog image", err, nil)
+		writeError(w, r, "Failed to get dog image", err, "breed", breed, "sub_breed", subBreed)
 		return
 	}
-	slog.Info("Service completed", "breed", breed, "image_url", imageURL)
+	slog.InfoContext(r.Context(), "Service completed", "breed", breed, "image_url", dog.ImageURL)
 
 	// Return success response
 	response := dogs.DogImageResponse{
-		ImageURL: imageURL,
-		Breed:    breed,
+		ImageURL:     dog.ImageURL,
+		ThumbnailURL: dog.ThumbnailURL,
+		MediumURL:    dog.MediumURL,
+		Breed:        breed,
+		SubBreed:     subBreed,
+		Width:        dog.Width,
+		Height:       dog.Height,
+		MIMEType:     dog.MIMEType,
 	}
 	httputils.WriteResponse(w, http.StatusOK, "Dog image retrieved successfully", nil, response)
 }
+
+// GetRandomDogImagesByBreed godoc
+//
+//	@Summary		Ingest several random dog images by breed
+//	@Description	Retrieves count random images for the breed, downloads and uploads them to S3 in parallel and saves them. Failed images are reported per item and do not fail the request
+//	@Tags			Dogs
+//	@Param			breed	path	string	true	"Dog breed"
+//	@Param			count	query	int		true	"Number of images"
+//	@Produce		json
+//	@Success		200	{object}	dogs.BatchImagesResponse
+//	@Failure		400	{object}	httputils.ErrorResponse
+//	@Failure		404	{object}	httputils.ErrorResponse
+//	@Failure		500	{object}	httputils.ErrorResponse
+//	@Failure		502	{object}	httputils.ErrorResponse
+//	@Failure		504	{object}	httputils.ErrorResponse
+//	@Router			/api/v1/dogs/{breed}/images [post]
+func (h *Handler) GetRandomDogImagesByBreed(w http.ResponseWriter, r *http.Request) {
+	h.getRandomDogImages(w, r)
+}
+
+// GetRandomDogImagesBySubBreed godoc
+//
+//	@Summary		Ingest several random dog images by sub-breed
+//	@Description	Retrieves count random images for the sub-breed, downloads and uploads them to S3 in parallel and saves them. Failed images are reported per item and do not fail the request
+//	@Tags			Dogs
+//	@Param			breed		path	string	true	"Dog breed"
+//	@Param			subBreed	path	string	true	"Dog sub-breed"
+//	@Param			count		query	int		true	"Number of images"
+//	@Produce		json
+//	@Success		200	{object}	dogs.BatchImagesResponse
+//	@Failure		400	{object}	httputils.ErrorResponse
+//	@Failure		404	{object}	httputils.ErrorResponse
+//	@Failure		500	{object}	httputils.ErrorResponse
+//	@Failure		502	{object}	httputils.ErrorResponse
+//	@Failure		504	{object}	httputils.ErrorResponse
+//	@Router			/api/v1/dogs/{breed}/{subBreed}/images [post]
+func (h *Handler) GetRandomDogImagesBySubBreed(w http.ResponseWriter, r *http.Request) {
+	h.getRandomDogImages(w, r)
+}
+
+// getRandomDogImages ingests images of the breed and, when the route has one, of its sub-breed
+func (h *Handler) getRandomDogImages(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	breed, subBreed := vars["breed"], vars["subBreed"]
+	if breed == "" {
+		httputils.WriteResponse(w, http.StatusBadRequest, "Breed parameter is required", nil, nil)
+		return
+	}
+
+	count, err := strconv.Atoi(r.URL.Query().Get("count"))
+	if err != nil || count <= 0 {
+		httputils.WriteResponse(w, http.StatusBadRequest, "count must be a positive integer", err, nil)
+		return
+	}
+	slog.InfoContext(r.Context(), "Processing batch dog image request", "breed", breed, "sub_breed", subBreed, "count", count)
+
+	response, err := h.dogsService.GetRandomDogImages(r.Context(), breed, subBreed, count, nil)
+	if err != nil {
+		writeError(w, r, "Failed to get dog images", err, "breed", breed, "sub_breed", subBreed)
+		return
+	}
+	slog.InfoContext(r.Context(), "Service completed", "breed", breed, "succeeded", response.Succeeded, "failed", response.Failed)
+
+	httputils.WriteResponse(w, http.StatusOK, "Dog images retrieved successfully", nil, response)
+}
+
+// defaultUploadContentType is signed when the client does not say what it uploads
+const defaultUploadContentType = "image/jpeg"
+
+// CreateUploadURL godoc
+//
+//	@Summary		Get a presigned upload URL
+//	@Description	Issues a presigned PUT request so the client can upload its own dog photo directly to S3. The returned headers must be sent with the upload
+//	@Tags			Dogs
+//	@Param			breed			path	string	true	"Dog breed"
+//	@Param			content_type	query	string	false	"Image content type"	default(image/jpeg)
+//	@Produce		json
+//	@Success		200	{object}	dogs.UploadURLResponse
+//	@Failure		400	{object}	httputils.ErrorResponse
+//	@Failure		404	{object}	httputils.ErrorResponse
+//	@Failure		500	{object}	httputils.ErrorResponse
+//	@Router			/api/v1/dogs/{breed}/uploads [post]
+func (h *Handler) CreateUploadURL(w http.ResponseWriter, r *http.Request) {
+	breed := mux.Vars(r)["breed"]
+	if breed == "" {
+		httputils.WriteResponse(w, http.StatusBadRequest, "Breed parameter is required", nil, nil)
+		return
+	}
+
+	contentType := r.URL.Query().Get("content_type")
+	if contentType == "" {
+		contentType = defaultUploadContentType
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		httputils.WriteResponse(w, http.StatusBadRequest, "content_type must be an image type", nil, nil)
+		return
+	}
+
+	response, err := h.dogsService.CreateUploadURL(r.Context(), breed, contentType)
+	if err != nil {
+		writeError(w, r, "Failed to create upload URL", err, "breed", breed)
+		return
+	}
+
+	httputils.WriteResponse(w, http.StatusOK, "Upload URL created successfully", nil, response)
+}
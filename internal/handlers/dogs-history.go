@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-platform/internal/models/dogs"
+	httputils "go-platform/pkg/utils/http-utils"
+
+	"github.com/gorilla/mux"
+)
+
+// GetDogByID godoc
+//
+//	@Summary		Get archived dog by ID
+//	@Description	Returns a previously archived dog image from the storage
+//	@Tags			Dogs
+//	@Param			id	path	string	true	"Dog ID"
+//	@Produce		json
+//	@Success		200	{object}	dogs.Dog
+//	@Failure		404	{object}	httputils.ErrorResponse
+//	@Failure		500	{object}	httputils.ErrorResponse
+//	@Router			/api/v1/dogs/{id} [get]
+func (h *Handler) GetDogByID(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	dog, err := h.dogsService.GetDog(r.Context(), id)
+	if err != nil {
+		writeError(w, r, "Failed to get dog", err, "id", id)
+		return
+	}
+
+	httputils.WriteResponse(w, http.StatusOK, "Dog retrieved successfully", nil, dog)
+}
+
+// ListDogs godoc
+//
+//	@Summary		List archived dogs
+//	@Description	Returns archived dog images ordered from newest to oldest with cursor pagination
+//	@Tags			Dogs
+//	@Param			breed	query	string	false	"Filter by breed"
+//	@Param			from	query	string	false	"Created at or after (RFC3339)"
+//	@Param			to		query	string	false	"Created before (RFC3339)"
+//	@Param			cursor	query	string	false	"Cursor from the previous page"
+//	@Param			limit	query	int		false	"Page size (default 20, max 100)"
+//	@Produce		json
+//	@Success		200	{object}	dogs.DogsPage
+//	@Failure		400	{object}	httputils.ErrorResponse
+//	@Failure		500	{object}	httputils.ErrorResponse
+//	@Router			/api/v1/dogs [get]
+func (h *Handler) ListDogs(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseListDogsFilter(r)
+	if err != nil {
+		httputils.WriteResponse(w, http.StatusBadRequest, "Invalid query parameters", err, nil)
+		return
+	}
+
+	page, err := h.dogsService.ListDogs(r.Context(), filter)
+	if err != nil {
+		writeError(w, r, "Failed to list dogs", err)
+		return
+	}
+
+	httputils.WriteResponse(w, http.StatusOK, "Dogs retrieved successfully", nil, page)
+}
+
+func parseListDogsFilter(r *http.Request) (dogs.ListDogsFilter, error) {
+	query := r.URL.Query()
+	filter := dogs.ListDogsFilter{
+		Breed:  query.Get("breed"),
+		Cursor: query.Get("cursor"),
+	}
+
+	if v := query.Get("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.New("from must be an RFC3339 timestamp")
+		}
+		filter.From = from
+	}
+
+	if v := query.Get("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.New("to must be an RFC3339 timestamp")
+		}
+		filter.To = to
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			return filter, errors.New("limit must be a non-negative integer, 0 uses the default page size")
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
@@ -1,17 +1,60 @@
 package handlers
 
-import "context"
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"go-platform/internal/models/breeds"
+	"go-platform/internal/models/dogs"
+	"go-platform/internal/models/jobs"
+	"go-platform/pkg/utils/errs"
+	httputils "go-platform/pkg/utils/http-utils"
+)
 
 type DogsService interface {
-	GetRandomDogImage(ctx context.Context, breed string) (string, error)
+	GetRandomDogImage(ctx context.Context, breed, subBreed string) (*dogs.Dog, error)
+	GetRandomDogImages(ctx context.Context, breed, subBreed string, n int, onResult func(dogs.BatchImageResult)) (*dogs.BatchImagesResponse, error)
+	GetDog(ctx context.Context, id string) (*dogs.Dog, error)
+	ListDogs(ctx context.Context, filter dogs.ListDogsFilter) (*dogs.DogsPage, error)
+	CreateUploadURL(ctx context.Context, breed, contentType string) (*dogs.UploadURLResponse, error)
+}
+
+type BreedsService interface {
+	ListBreeds(ctx context.Context) ([]breeds.Breed, error)
+}
+
+type JobsService interface {
+	SubmitDogImage(ctx context.Context, breed, subBreed string) (*jobs.Job, error)
+	GetJob(ctx context.Context, id string) (*jobs.Job, error)
 }
 
 type Handler struct {
-	dogsService DogsService
+	dogsService   DogsService
+	jobsService   JobsService
+	breedsService BreedsService
 }
 
-func NewHandler(dogsService DogsService) *Handler {
+func NewHandler(dogsService DogsService, jobsService JobsService, breedsService BreedsService) *Handler {
 	return &Handler{
-		dogsService: dogsService,
+		dogsService:   dogsService,
+		jobsService:   jobsService,
+		breedsService: breedsService,
 	}
 }
+
+// writeError writes the response of a failed service call with the status of the error kind.
+// Errors caused by the request are logged as info, abandoned requests as warnings and the rest as errors.
+func writeError(w http.ResponseWriter, r *http.Request, message string, err error, args ...any) {
+	args = append(args, "kind", errs.KindOf(err).String(), "error", err)
+	switch errs.KindOf(err) {
+	case errs.NotFound, errs.InvalidArgument, errs.Conflict:
+		slog.InfoContext(r.Context(), message, args...)
+	case errs.Canceled, errs.Timeout:
+		slog.WarnContext(r.Context(), "Request cancelled", args...)
+	default:
+		slog.ErrorContext(r.Context(), message, args...)
+	}
+
+	httputils.WriteResponse(w, http.StatusInternalServerError, message, err, nil)
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go-platform/internal/models/jobs"
+	httputils "go-platform/pkg/utils/http-utils"
+
+	"github.com/gorilla/mux"
+)
+
+// SubmitDogImageJob godoc
+//
+//	@Summary		Queue dog image ingestion
+//	@Description	Queues fetching a random dog image for the breed, uploading it to S3 and saving it. Poll the job for the result
+//	@Tags			Dogs
+//	@Param			breed	path	string	true	"Dog breed"
+//	@Produce		json
+//	@Success		202	{object}	jobs.JobAcceptedResponse
+//	@Failure		400	{object}	httputils.ErrorResponse
+//	@Failure		404	{object}	httputils.ErrorResponse
+//	@Failure		500	{object}	httputils.ErrorResponse
+//	@Failure		503	{object}	httputils.ErrorResponse
+//	@Router			/api/v1/dogs/{breed}/image:async [post]
+func (h *Handler) SubmitDogImageJob(w http.ResponseWriter, r *http.Request) {
+	h.submitDogImageJob(w, r)
+}
+
+// SubmitSubBreedDogImageJob godoc
+//
+//	@Summary		Queue dog image ingestion by sub-breed
+//	@Description	Queues fetching a random dog image for the sub-breed, uploading it to S3 and saving it. Poll the job for the result
+//	@Tags			Dogs
+//	@Param			breed		path	string	true	"Dog breed"
+//	@Param			subBreed	path	string	true	"Dog sub-breed"
+//	@Produce		json
+//	@Success		202	{object}	jobs.JobAcceptedResponse
+//	@Failure		400	{object}	httputils.ErrorResponse
+//	@Failure		404	{object}	httputils.ErrorResponse
+//	@Failure		500	{object}	httputils.ErrorResponse
+//	@Failure		503	{object}	httputils.ErrorResponse
+//	@Router			/api/v1/dogs/{breed}/{subBreed}/image:async [post]
+func (h *Handler) SubmitSubBreedDogImageJob(w http.ResponseWriter, r *http.Request) {
+	h.submitDogImageJob(w, r)
+}
+
+// submitDogImageJob queues ingestion for the breed and, when the route has one, its sub-breed
+func (h *Handler) submitDogImageJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	breed, subBreed := vars["breed"], vars["subBreed"]
+	if breed == "" {
+		httputils.WriteResponse(w, http.StatusBadRequest, "Breed parameter is required", nil, nil)
+		return
+	}
+
+	job, err := h.jobsService.SubmitDogImage(r.Context(), breed, subBreed)
+	if err != nil {
+		writeError(w, r, "Failed to submit job", err, "breed", breed, "sub_breed", subBreed)
+		return
+	}
+
+	response := jobs.JobAcceptedResponse{
+		JobID:     job.ID,
+		Status:    job.Status,
+		StatusURL: "/api/v1/jobs/" + job.ID,
+	}
+	w.Header().Set("Location", response.StatusURL)
+	httputils.WriteResponse(w, http.StatusAccepted, "Job queued", nil, response)
+}
+
+// GetJob godoc
+//
+//	@Summary		Get job status
+//	@Description	Returns the status of an asynchronous ingestion job and the S3 URL once it succeeded
+//	@Tags			Jobs
+//	@Param			id	path	string	true	"Job ID"
+//	@Produce		json
+//	@Success		200	{object}	jobs.Job
+//	@Failure		404	{object}	httputils.ErrorResponse
+//	@Failure		500	{object}	httputils.ErrorResponse
+//	@Router			/api/v1/jobs/{id} [get]
+func (h *Handler) GetJob(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	job, err := h.jobsService.GetJob(r.Context(), id)
+	if err != nil {
+		writeError(w, r, "Failed to get job", err, "id", id)
+		return
+	}
+
+	httputils.WriteResponse(w, http.StatusOK, "Job retrieved successfully", nil, job)
+}
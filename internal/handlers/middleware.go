@@ -5,17 +5,74 @@ import (
 	"net/http"
 
 	"go-platform/pkg/metrics"
+	"go-platform/pkg/requestid"
+	"go-platform/pkg/tracer"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// RequestIDMiddleware accepts the X-Request-ID of the client or generates one,
+// echoes it in the response and stores it in the request context for logs and outbound calls
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := requestid.Resolve(r.Header.Get(requestid.Header))
+		w.Header().Set(requestid.Header, id)
+
+		next.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+	})
+}
+
+// unmatchedRoute names the spans of requests matching no route, so scanned paths share one span name
+const unmatchedRoute = "unmatched"
+
+// TracingMiddleware continues the trace of the client or starts one and serves the request in a server span
+// named after the route template, so requests for different breeds share one span name
+func TracingMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := unmatchedRoute
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer.StartSpan(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethod(r.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(r.URL.Path),
+				attribute.String("request_id", requestid.FromContext(ctx)),
+			),
+		)
+		defer span.End()
+
+		lrw := &LoggingResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(lrw, r.WithContext(ctx))
+
+		span.SetAttributes(semconv.HTTPStatusCode(lrw.statusCode))
+		if lrw.statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(lrw.statusCode))
+		}
+	})
+}
+
 // LoggingMiddleware logs the details of each request and response
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		slog.Info("Received request", "method", r.Method, "path", r.URL.Path)
+		slog.InfoContext(r.Context(), "Received request", "method", r.Method, "path", r.URL.Path)
 
 		lrw := &LoggingResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 		next.ServeHTTP(lrw, r)
 
-		slog.Info("Response status", "status", lrw.statusCode)
+		slog.InfoContext(r.Context(), "Response status", "status", lrw.statusCode)
 	})
 }
 
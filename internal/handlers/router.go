@@ -13,12 +13,28 @@ import (
 func InitRouter(h *Handler, httpMetrics *metrics.HTTPMetrics) *mux.Router {
 	router := mux.NewRouter()
 
-	// Add metrics middleware first (to capture all requests)
+	// Add request ID middleware first (so every later middleware logs with it)
+	router.Use(RequestIDMiddleware)
+
+	// Add tracing middleware (so metrics and logs of the request carry its trace)
+	router.Use(TracingMiddleware)
+
+	// Add metrics middleware (to capture all requests)
 	router.Use(MetricsMiddleware(httpMetrics))
 
 	// Add logging middleware
 	router.Use(LoggingMiddleware)
 
+	// Middlewares only run for matched routes, unmatched requests go through the same chain by hand
+	// and are counted and traced under one label
+	unmatched := func(next http.Handler) http.Handler {
+		return RequestIDMiddleware(TracingMiddleware(MetricsMiddleware(httpMetrics)(LoggingMiddleware(next))))
+	}
+	router.NotFoundHandler = unmatched(http.NotFoundHandler())
+	router.MethodNotAllowedHandler = unmatched(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+
 	// Health
 	{
 		router.HandleFunc("/live", h.Health).Methods(http.MethodGet)
@@ -35,7 +51,25 @@ func InitRouter(h *Handler, httpMetrics *metrics.HTTPMetrics) *mux.Router {
 
 	// Dogs
 	{
+		router.HandleFunc("/api/v1/dogs", h.ListDogs).Methods(http.MethodGet)
+		router.HandleFunc("/api/v1/dogs/{id}", h.GetDogByID).Methods(http.MethodGet)
 		router.HandleFunc("/api/v1/dogs/{breed}/image", h.GetRandomDogImageByBreed).Methods(http.MethodGet)
+		router.HandleFunc("/api/v1/dogs/{breed}/images", h.GetRandomDogImagesByBreed).Methods(http.MethodPost)
+		router.HandleFunc("/api/v1/dogs/{breed}/uploads", h.CreateUploadURL).Methods(http.MethodPost)
+		router.HandleFunc("/api/v1/dogs/{breed}/image:async", h.SubmitDogImageJob).Methods(http.MethodPost)
+		router.HandleFunc("/api/v1/dogs/{breed}/{subBreed}/image", h.GetRandomDogImageBySubBreed).Methods(http.MethodGet)
+		router.HandleFunc("/api/v1/dogs/{breed}/{subBreed}/images", h.GetRandomDogImagesBySubBreed).Methods(http.MethodPost)
+		router.HandleFunc("/api/v1/dogs/{breed}/{subBreed}/image:async", h.SubmitSubBreedDogImageJob).Methods(http.MethodPost)
+	}
+
+	// Breeds
+	{
+		router.HandleFunc("/api/v1/breeds", h.ListBreeds).Methods(http.MethodGet)
+	}
+
+	// Jobs
+	{
+		router.HandleFunc("/api/v1/jobs/{id}", h.GetJob).Methods(http.MethodGet)
 	}
 
 	return router
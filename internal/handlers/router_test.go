@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-platform/pkg/config"
+	"go-platform/pkg/metrics"
+	"go-platform/pkg/requestid"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestUnmatchedRequestsRunThroughMiddlewares(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	httpMetrics, err := metrics.NewHTTPMetrics(prometheus.NewRegistry(), config.HTTPMetricsConfig{DurationBuckets: "0.1,1", SizeBuckets: "100,1000"})
+	if err != nil {
+		t.Fatalf("NewHTTPMetrics() error = %v", err)
+	}
+	router := InitRouter(&Handler{}, httpMetrics)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantSpan   string
+	}{
+		{name: "not found", method: http.MethodGet, path: "/wp-admin/setup.php", wantStatus: http.StatusNotFound, wantSpan: "GET unmatched"},
+		{name: "method not allowed", method: http.MethodDelete, path: "/live", wantStatus: http.StatusMethodNotAllowed, wantSpan: "DELETE unmatched"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set(requestid.Header, "client-id")
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get(requestid.Header); got != "client-id" {
+				t.Fatalf("%s = %q, want %q", requestid.Header, got, "client-id")
+			}
+
+			spans := recorder.Ended()
+			if len(spans) == 0 {
+				t.Fatal("no span recorded")
+			}
+			if got := spans[len(spans)-1].Name(); got != tt.wantSpan {
+				t.Fatalf("span name = %q, want %q", got, tt.wantSpan)
+			}
+		})
+	}
+}
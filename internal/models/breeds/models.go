@@ -0,0 +1,76 @@
+package breeds
+
+import (
+	"sort"
+	"time"
+)
+
+// Breed is a breed of the catalog with its sub-breeds, both sorted by name
+type Breed struct {
+	Name      string   `json:"name"`
+	SubBreeds []string `json:"sub_breeds"`
+}
+
+// Row is a stored catalog row, SubBreed is empty for a breed without sub-breeds
+type Row struct {
+	Name     string    `db:"name"`
+	SubBreed string    `db:"sub_breed"`
+	SyncedAt time.Time `db:"synced_at"`
+}
+
+// BreedsListResponse is returned by the dog API for the list of all breeds
+type BreedsListResponse struct {
+	Message map[string][]string `json:"message"`
+	Status  string              `json:"status"`
+}
+
+// BreedsResponse is returned by the breeds API
+type BreedsResponse struct {
+	Breeds []Breed `json:"breeds"`
+	Count  int     `json:"count"`
+}
+
+// FromMap builds a sorted catalog from breed names mapped to their sub-breeds
+func FromMap(list map[string][]string) []Breed {
+	catalog := make([]Breed, 0, len(list))
+	for name, subBreeds := range list {
+		subs := append([]string{}, subBreeds...)
+		sort.Strings(subs)
+		catalog = append(catalog, Breed{Name: name, SubBreeds: subs})
+	}
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Name < catalog[j].Name })
+
+	return catalog
+}
+
+// ToRows flattens the catalog into stored rows
+func ToRows(catalog []Breed, syncedAt time.Time) []Row {
+	var rows []Row
+	for _, breed := range catalog {
+		if len(breed.SubBreeds) == 0 {
+			rows = append(rows, Row{Name: breed.Name, SyncedAt: syncedAt})
+			continue
+		}
+		for _, sub := range breed.SubBreeds {
+			rows = append(rows, Row{Name: breed.Name, SubBreed: sub, SyncedAt: syncedAt})
+		}
+	}
+
+	return rows
+}
+
+// FromRows groups stored rows ordered by name and sub-breed into the catalog
+func FromRows(rows []Row) []Breed {
+	var catalog []Breed
+	for _, row := range rows {
+		if len(catalog) == 0 || catalog[len(catalog)-1].Name != row.Name {
+			catalog = append(catalog, Breed{Name: row.Name, SubBreeds: []string{}})
+		}
+		if row.SubBreed != "" {
+			last := &catalog[len(catalog)-1]
+			last.SubBreeds = append(last.SubBreeds, row.SubBreed)
+		}
+	}
+
+	return catalog
+}
@@ -0,0 +1,58 @@
+package dogs
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"go-platform/pkg/utils/errs"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+var ErrInvalidCursor = errs.New(errs.InvalidArgument, "invalid cursor")
+
+// Cursor points at the last row of a page. Rows are ordered by (created_at, id) descending,
+// so the next page starts strictly after this position.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// EncodeCursor builds an opaque cursor for the given row
+func EncodeCursor(dog Dog) string {
+	raw := dog.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + dog.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor
+func DecodeCursor(cursor string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	ts, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return Cursor{CreatedAt: ts, ID: id}, nil
+}
+
+// NewDogsPage trims the extra row fetched by repositories (limit+1) and sets the next cursor
+func NewDogsPage(dogs []Dog, limit int) *DogsPage {
+	page := &DogsPage{Dogs: dogs}
+	if page.Dogs == nil {
+		page.Dogs = []Dog{}
+	}
+	if limit > 0 && len(dogs) > limit {
+		page.Dogs = dogs[:limit]
+		page.NextCursor = EncodeCursor(page.Dogs[limit-1])
+	}
+	return page
+}
@@ -0,0 +1,86 @@
+package dogs
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		dog  Dog
+	}{
+		{name: "numeric ID", dog: Dog{ID: "42", CreatedAt: time.Date(2024, 5, 1, 12, 30, 0, 123456789, time.UTC)}},
+		{name: "UUID", dog: Dog{ID: "0b7e4c1a-5d0f-4b59-9e0c-3f4b1f0c2a11", CreatedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}},
+		{name: "local time", dog: Dog{ID: "7", CreatedAt: time.Date(2024, 5, 1, 14, 0, 0, 500, time.FixedZone("CEST", 2*60*60))}},
+		{name: "separator in ID", dog: Dog{ID: "a|b", CreatedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(EncodeCursor(tt.dog))
+			if err != nil {
+				t.Fatalf("DecodeCursor() error = %v", err)
+			}
+			if got.ID != tt.dog.ID || !got.CreatedAt.Equal(tt.dog.CreatedAt) {
+				t.Fatalf("DecodeCursor() = %+v, want %s at %s", got, tt.dog.ID, tt.dog.CreatedAt)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "not a cursor!"},
+		{name: "padded base64", cursor: base64.URLEncoding.EncodeToString([]byte("2024-05-01T00:00:00Z|1"))},
+		{name: "no separator", cursor: encode("2024-05-01T00:00:00Z")},
+		{name: "empty ID", cursor: encode("2024-05-01T00:00:00Z|")},
+		{name: "bad time", cursor: encode("yesterday|1")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.cursor); !errors.Is(err, ErrInvalidCursor) {
+				t.Fatalf("DecodeCursor(%q) error = %v, want %v", tt.cursor, err, ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+func TestNewDogsPage(t *testing.T) {
+	at := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	rows := []Dog{{ID: "3", CreatedAt: at}, {ID: "2", CreatedAt: at}, {ID: "1", CreatedAt: at}}
+
+	tests := []struct {
+		name       string
+		dogs       []Dog
+		limit      int
+		wantLen    int
+		wantCursor string
+	}{
+		{name: "extra row", dogs: rows, limit: 2, wantLen: 2, wantCursor: EncodeCursor(rows[1])},
+		{name: "last page", dogs: rows, limit: 3, wantLen: 3},
+		{name: "no rows", dogs: nil, limit: 2, wantLen: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := NewDogsPage(tt.dogs, tt.limit)
+			if page.Dogs == nil {
+				t.Fatal("NewDogsPage() dogs are nil, want an empty slice")
+			}
+			if len(page.Dogs) != tt.wantLen || page.NextCursor != tt.wantCursor {
+				t.Fatalf("NewDogsPage() = %d dogs and cursor %q, want %d and %q", len(page.Dogs), page.NextCursor, tt.wantLen, tt.wantCursor)
+			}
+		})
+	}
+}
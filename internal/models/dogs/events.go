@@ -0,0 +1,44 @@
+package dogs
+
+import "time"
+
+const (
+	ImageArchivedEventType     = "dog.image.archived"
+	ImageArchivedSchemaVersion = 1
+)
+
+// ImageArchivedEvent is published after a dog image is uploaded to S3 and saved to the storage.
+// Consumers should fetch the image by S3Key: URL is presigned and may expire before the event is read.
+// Schema: api/events/dog.image.archived.v1.json
+type ImageArchivedEvent struct {
+	Version  int    `json:"version"`
+	ID       string `json:"id"`
+	Breed    string `json:"breed"`
+	SubBreed string `json:"sub_breed,omitempty"`
+	S3Key    string `json:"s3_key"`
+	// Deprecated: URL is kept for v1 consumers, it is a presigned URL valid for S3_PRESIGN_GET_EXPIRY.
+	URL        string    `json:"url"`
+	Size       int       `json:"size"`
+	ArchivedAt time.Time `json:"archived_at"`
+}
+
+func NewImageArchivedEvent(id string, dog *Dog, s3Key, url string, size int) *ImageArchivedEvent {
+	return &ImageArchivedEvent{
+		Version:    ImageArchivedSchemaVersion,
+		ID:         id,
+		Breed:      dog.Breed,
+		SubBreed:   dog.SubBreed,
+		S3Key:      s3Key,
+		URL:        url,
+		Size:       size,
+		ArchivedAt: dog.CreatedAt,
+	}
+}
+
+func (e *ImageArchivedEvent) EventType() string {
+	return ImageArchivedEventType
+}
+
+func (e *ImageArchivedEvent) SchemaVersion() int {
+	return e.Version
+}
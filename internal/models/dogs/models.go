@@ -1,20 +1,144 @@
 package dogs
 
-import "time"
+import (
+	"time"
+
+	"go-platform/pkg/utils/errs"
+)
+
+// ErrDogNotFound is returned by repositories when no dog matches the requested ID
+var ErrDogNotFound = errs.New(errs.NotFound, "dog not found")
+
+// ErrBreedNotFound is returned by the dog API client when the upstream does not know the breed
+var ErrBreedNotFound = errs.New(errs.NotFound, "breed not found")
+
+// BreedPath joins a breed and its optional sub-breed the way dog.ceo does, e.g. hound/afghan
+func BreedPath(breed, subBreed string) string {
+	if subBreed == "" {
+		return breed
+	}
+	return breed + "/" + subBreed
+}
 
 type DogResponse struct {
 	Message string `json:"message"`
 	Status  string `json:"status"`
 }
 
+// DogImagesResponse is returned by the dog API for multiple random images
+type DogImagesResponse struct {
+	Message []string `json:"message"`
+	Status  string   `json:"status"`
+}
+
 type DogImageResponse struct {
-	ImageURL string `json:"image_url"`
-	Breed    string `json:"breed"`
+	ImageURL     string `json:"image_url"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	MediumURL    string `json:"medium_url,omitempty"`
+	Breed        string `json:"breed"`
+	SubBreed     string `json:"sub_breed,omitempty"`
+	Width        int    `json:"width,omitempty"`
+	Height       int    `json:"height,omitempty"`
+	MIMEType     string `json:"mime_type,omitempty"`
 }
 
 type Dog struct {
-	ID        string    `json:"id"`
-	ImageURL  string    `json:"image_url"`
-	Breed     string    `json:"breed"`
-	CreatedAt time.Time `json:"created_at"`
+	ID string `json:"id" db:"id"`
+	// ImageKey is the S3 object key, ImageURL is presigned from it when the dog is served.
+	// Rows archived before keys were stored only have ImageURL.
+	ImageKey string `json:"image_key,omitempty" db:"image_key"`
+	ImageURL string `json:"image_url" db:"image_url"`
+	Breed    string `json:"breed" db:"breed"`
+	// SubBreed is set for dog.ceo style sub-breeds like hound/afghan
+	SubBreed string `json:"sub_breed,omitempty" db:"sub_breed"`
+	// ContentHash is the hex SHA-256 of the image bytes, identical images share it
+	ContentHash string `json:"content_hash,omitempty" db:"content_hash"`
+	Width       int    `json:"width,omitempty" db:"width"`
+	Height      int    `json:"height,omitempty" db:"height"`
+	MIMEType    string `json:"mime_type,omitempty" db:"mime_type"`
+	// Renditions are JPEGs stored next to the original, their URLs are presigned like ImageURL
+	ThumbnailKey string    `json:"thumbnail_key,omitempty" db:"thumbnail_key"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty" db:"-"`
+	MediumKey    string    `json:"medium_key,omitempty" db:"medium_key"`
+	MediumURL    string    `json:"medium_url,omitempty" db:"-"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// ContentHashes returns the distinct non-empty content hashes of dogs
+func ContentHashes(batch []*Dog) []string {
+	seen := make(map[string]struct{}, len(batch))
+	hashes := make([]string, 0, len(batch))
+	for _, dog := range batch {
+		if dog.ContentHash == "" {
+			continue
+		}
+		if _, ok := seen[dog.ContentHash]; ok {
+			continue
+		}
+		seen[dog.ContentHash] = struct{}{}
+		hashes = append(hashes, dog.ContentHash)
+	}
+	return hashes
+}
+
+// SplitByContentHash replaces dogs already stored under the same content hash with the stored rows.
+// It returns the ID of every dog, empty for dogs still to be inserted, and the positions of those dogs.
+func SplitByContentHash(batch []*Dog, existing map[string]Dog) ([]string, []int) {
+	ids := make([]string, len(batch))
+	fresh := make([]int, 0, len(batch))
+	for i, dog := range batch {
+		if stored, ok := existing[dog.ContentHash]; ok && dog.ContentHash != "" {
+			*dog = stored
+			ids[i] = stored.ID
+			continue
+		}
+		fresh = append(fresh, i)
+	}
+	return ids, fresh
+}
+
+// UploadURLResponse is a presigned request uploading a photo to Key.
+// Headers must be sent with the request as is, they are part of the signature.
+type UploadURLResponse struct {
+	Key       string            `json:"key"`
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// ListDogsFilter narrows down the dogs history. Zero values mean "no filter".
+// From is inclusive, To is exclusive.
+type ListDogsFilter struct {
+	Breed  string
+	From   time.Time
+	To     time.Time
+	Cursor string
+	Limit  int
+}
+
+// DogsPage is a single page of the dogs history ordered from newest to oldest
+type DogsPage struct {
+	Dogs       []Dog  `json:"dogs"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// BatchImageResult is the outcome of archiving a single image of a batch
+type BatchImageResult struct {
+	Index        int    `json:"index"`
+	SourceURL    string `json:"source_url"`
+	ImageURL     string `json:"image_url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	MediumURL    string `json:"medium_url,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// BatchImagesResponse reports every image of a batch, failed items do not fail the batch
+type BatchImagesResponse struct {
+	Breed     string             `json:"breed"`
+	SubBreed  string             `json:"sub_breed,omitempty"`
+	Requested int                `json:"requested"`
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Results   []BatchImageResult `json:"results"`
 }
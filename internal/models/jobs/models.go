@@ -0,0 +1,53 @@
+package jobs
+
+import (
+	"time"
+
+	"go-platform/pkg/utils/errs"
+)
+
+var (
+	// ErrJobNotFound is returned when the job is unknown or already expired
+	ErrJobNotFound = errs.New(errs.NotFound, "job not found")
+	// ErrQueueFull is returned when the worker pool cannot accept more jobs
+	ErrQueueFull = errs.New(errs.Unavailable, "job queue is full")
+)
+
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Finished reports whether the job reached a final status
+func (s Status) Finished() bool {
+	return s == StatusSucceeded || s == StatusFailed
+}
+
+// Job tracks an asynchronous dog image ingestion
+type Job struct {
+	ID       string `json:"id"`
+	Breed    string `json:"breed"`
+	SubBreed string `json:"sub_breed,omitempty"`
+	Status   Status `json:"status"`
+	// DogID is the archived dog of a succeeded job, ImageURL is presigned from it when the job is read
+	DogID    string `json:"dog_id,omitempty"`
+	ImageURL string `json:"image_url,omitempty"`
+	Error    string `json:"error,omitempty"`
+	// RequestID is the ID of the request that queued the job, its worker logs with it
+	RequestID string `json:"request_id,omitempty"`
+	// Instance is the process that queued the job, the queue lives in its memory
+	Instance  string    `json:"instance,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// JobAcceptedResponse is returned when a job is queued
+type JobAcceptedResponse struct {
+	JobID     string `json:"job_id"`
+	Status    Status `json:"status"`
+	StatusURL string `json:"status_url"`
+}
@@ -0,0 +1,47 @@
+package objects
+
+import (
+	"errors"
+	"time"
+
+	"go-platform/pkg/utils/errs"
+)
+
+var (
+	// ErrObjectNotFound is returned by the S3 client when the key is not in the bucket
+	ErrObjectNotFound = errs.New(errs.NotFound, "object not found")
+	// ErrObjectTooLarge is returned when an upload exceeds the configured maximum object size
+	ErrObjectTooLarge = errors.New("object too large")
+)
+
+// User metadata keys stored with archived images
+const (
+	MetadataBreed     = "breed"
+	MetadataSourceURL = "source-url"
+)
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Key          string            `json:"key"`
+	Size         int64             `json:"size"`
+	ContentType  string            `json:"content_type,omitempty"`
+	ETag         string            `json:"etag,omitempty"`
+	ContentHash  string            `json:"content_hash,omitempty"` // hex SHA-256, set for content-addressed uploads
+	LastModified time.Time         `json:"last_modified"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+// ObjectsPage is a single page of a listing, NextToken is empty on the last page
+type ObjectsPage struct {
+	Objects   []ObjectInfo `json:"objects"`
+	NextToken string       `json:"next_token,omitempty"`
+}
+
+// PresignedRequest is a time-limited request to S3 that can be sent without credentials.
+// Headers must be sent as is, they are part of the signature.
+type PresignedRequest struct {
+	URL       string            `json:"url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
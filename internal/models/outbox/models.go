@@ -0,0 +1,30 @@
+package outbox
+
+import (
+	"sort"
+	"time"
+)
+
+// Message is an event waiting in the outbox table to be relayed to the broker
+type Message struct {
+	ID        string            `json:"id"`
+	EventType string            `json:"event_type"`
+	Payload   []byte            `json:"payload"`
+	Headers   map[string]string `json:"headers"`
+	Attempts  int               `json:"attempts"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// MessageFunc builds the outbox message once the ID of the inserted row is known.
+// Repositories call it inside the insert transaction.
+type MessageFunc func(id string) (*Message, error)
+
+// BatchMessageFunc builds the outbox message for the index-th row of a multi-row insert
+type BatchMessageFunc func(index int, id string) (*Message, error)
+
+// SortByCreatedAt orders messages oldest first, the relay order
+func SortByCreatedAt(messages []Message) {
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+	})
+}
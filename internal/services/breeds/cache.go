@@ -0,0 +1,43 @@
+package breeds
+
+import (
+	"context"
+
+	"go-platform/internal/models/breeds"
+	"go-platform/pkg/cache/redis"
+	"go-platform/pkg/config"
+)
+
+// catalogKey is the only key of the breeds family, the catalog is listed whole
+const catalogKey = "all"
+
+// cachedRepository caches the breed catalog until the next sync replaces it
+type cachedRepository struct {
+	Repository
+	cache  *redis.Cache
+	family redis.Family
+}
+
+// NewCachedRepository wraps the repository with a read-through cache of the breed list
+func NewCachedRepository(repository Repository, cache *redis.Cache, cfg config.CacheConfig) Repository {
+	return &cachedRepository{
+		Repository: repository,
+		cache:      cache,
+		family:     redis.Family{Name: "breeds_list", TTL: cfg.BreedsTTL},
+	}
+}
+
+// ReplaceBreeds drops the cached list so every replica serves the new catalog right away
+func (r *cachedRepository) ReplaceBreeds(ctx context.Context, catalog []breeds.Breed) error {
+	if err := r.Repository.ReplaceBreeds(ctx, catalog); err != nil {
+		return err
+	}
+
+	r.cache.Delete(ctx, r.family, catalogKey)
+
+	return nil
+}
+
+func (r *cachedRepository) ListBreeds(ctx context.Context) ([]breeds.Breed, error) {
+	return redis.GetOrLoad(ctx, r.cache, r.family, catalogKey, r.Repository.ListBreeds)
+}
@@ -0,0 +1,153 @@
+package breeds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go-platform/internal/models/breeds"
+	"go-platform/pkg/config"
+	"go-platform/pkg/utils/errs"
+)
+
+type Source interface {
+	ListAllBreeds(ctx context.Context) (map[string][]string, error)
+}
+
+type Repository interface {
+	ReplaceBreeds(ctx context.Context, catalog []breeds.Breed) error
+	ListBreeds(ctx context.Context) ([]breeds.Breed, error)
+}
+
+// BreedsService keeps the breed catalog in sync with the dog API and validates breeds against it.
+// The catalog is stored in the repository and held in memory for validation; until a catalog
+// is available every breed is accepted, so an unreachable dog API at startup does not block ingestion.
+type BreedsService struct {
+	source       Source
+	repository   Repository
+	syncEnabled  bool
+	syncInterval time.Duration
+
+	mu      sync.RWMutex
+	catalog map[string]map[string]struct{} // breed -> sub-breeds
+}
+
+func NewBreedsService(source Source, repository Repository, cfg config.BreedsConfig) *BreedsService {
+	return &BreedsService{
+		source:       source,
+		repository:   repository,
+		syncEnabled:  cfg.SyncEnabled,
+		syncInterval: cfg.SyncInterval,
+	}
+}
+
+// Run loads the stored catalog and syncs it periodically until ctx is cancelled
+func (s *BreedsService) Run(ctx context.Context) {
+	if err := s.load(ctx); err != nil {
+		slog.Error("Failed to load breeds", "error", err)
+	}
+
+	if !s.syncEnabled {
+		slog.Info("Breed sync disabled")
+		return
+	}
+
+	slog.Info("Starting breed sync", "interval", s.syncInterval)
+
+	ticker := time.NewTicker(s.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Sync(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("Failed to sync breeds", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Stopping breed sync")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync replaces the stored catalog with the breeds listed by the dog API
+func (s *BreedsService) Sync(ctx context.Context) error {
+	list, err := s.source.ListAllBreeds(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list breeds: %w", err)
+	}
+	// an empty answer is more likely an upstream fault than a catalog without breeds
+	if len(list) == 0 {
+		return errs.New(errs.UpstreamUnavailable, "dog API returned no breeds")
+	}
+
+	catalog := breeds.FromMap(list)
+	if err := s.repository.ReplaceBreeds(ctx, catalog); err != nil {
+		return fmt.Errorf("failed to save breeds: %w", err)
+	}
+	s.setCatalog(catalog)
+
+	slog.Info("Breeds synced", "count", len(catalog))
+	return nil
+}
+
+// ListBreeds returns the stored catalog ordered by name
+func (s *BreedsService) ListBreeds(ctx context.Context) ([]breeds.Breed, error) {
+	catalog, err := s.repository.ListBreeds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list breeds: %w", err)
+	}
+
+	return catalog, nil
+}
+
+// HasBreed reports whether the breed and, if set, its sub-breed are in the catalog,
+// every breed is known while there is no catalog
+func (s *BreedsService) HasBreed(_ context.Context, breed, subBreed string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.catalog == nil {
+		return true
+	}
+	subs, ok := s.catalog[breed]
+	if !ok || subBreed == "" {
+		return ok
+	}
+	_, ok = subs[subBreed]
+	return ok
+}
+
+// load fills the in-memory catalog from the repository
+func (s *BreedsService) load(ctx context.Context) error {
+	catalog, err := s.repository.ListBreeds(ctx)
+	if err != nil {
+		return err
+	}
+	if len(catalog) == 0 {
+		slog.Info("No stored breeds, accepting every breed until the first sync")
+		return nil
+	}
+
+	s.setCatalog(catalog)
+	slog.Info("Breeds loaded", "count", len(catalog))
+	return nil
+}
+
+func (s *BreedsService) setCatalog(catalog []breeds.Breed) {
+	index := make(map[string]map[string]struct{}, len(catalog))
+	for _, breed := range catalog {
+		subs := make(map[string]struct{}, len(breed.SubBreeds))
+		for _, sub := range breed.SubBreeds {
+			subs[sub] = struct{}{}
+		}
+		index[breed.Name] = subs
+	}
+
+	s.mu.Lock()
+	s.catalog = index
+	s.mu.Unlock()
+}
@@ -0,0 +1,96 @@
+package breeds
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go-platform/internal/models/breeds"
+	"go-platform/pkg/config"
+)
+
+// stubSource answers ListAllBreeds with list or err
+type stubSource struct {
+	list map[string][]string
+	err  error
+}
+
+func (s *stubSource) ListAllBreeds(context.Context) (map[string][]string, error) {
+	return s.list, s.err
+}
+
+// memoryRepository holds the last saved catalog
+type memoryRepository struct {
+	catalog  []breeds.Breed
+	replaces int
+}
+
+func (r *memoryRepository) ReplaceBreeds(_ context.Context, catalog []breeds.Breed) error {
+	r.catalog = catalog
+	r.replaces++
+	return nil
+}
+
+func (r *memoryRepository) ListBreeds(context.Context) ([]breeds.Breed, error) {
+	return r.catalog, nil
+}
+
+func TestSyncReplacesTheCatalog(t *testing.T) {
+	ctx := context.Background()
+	source := &stubSource{list: map[string][]string{"pug": nil, "hound": {"english", "afghan"}}}
+	repository := &memoryRepository{}
+	service := NewBreedsService(source, repository, config.BreedsConfig{})
+
+	// without a catalog every breed is accepted
+	if !service.HasBreed(ctx, "hund", "") {
+		t.Fatal("HasBreed() = false before the first sync, want true")
+	}
+
+	if err := service.Sync(ctx); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	want := []breeds.Breed{{Name: "hound", SubBreeds: []string{"afghan", "english"}}, {Name: "pug", SubBreeds: []string{}}}
+	if !reflect.DeepEqual(repository.catalog, want) {
+		t.Fatalf("saved catalog = %v, want %v", repository.catalog, want)
+	}
+
+	tests := []struct {
+		breed    string
+		subBreed string
+		want     bool
+	}{
+		{breed: "hound", want: true},
+		{breed: "hound", subBreed: "afghan", want: true},
+		{breed: "hound", subBreed: "basset", want: false},
+		{breed: "pug", want: true},
+		{breed: "pug", subBreed: "afghan", want: false},
+		{breed: "hund", want: false},
+	}
+	for _, tt := range tests {
+		if got := service.HasBreed(ctx, tt.breed, tt.subBreed); got != tt.want {
+			t.Fatalf("HasBreed(%q, %q) = %v, want %v", tt.breed, tt.subBreed, got, tt.want)
+		}
+	}
+}
+
+func TestSyncKeepsTheCatalogOnUpstreamFaults(t *testing.T) {
+	ctx := context.Background()
+	source := &stubSource{list: map[string][]string{"pug": nil}}
+	repository := &memoryRepository{}
+	service := NewBreedsService(source, repository, config.BreedsConfig{})
+	if err := service.Sync(ctx); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	for _, fault := range []*stubSource{{list: map[string][]string{}}, {err: errors.New("connection refused")}} {
+		*source = *fault
+		if err := service.Sync(ctx); err == nil {
+			t.Fatalf("Sync() with %+v error = nil, want an error", fault)
+		}
+	}
+
+	if repository.replaces != 1 || !service.HasBreed(ctx, "pug", "") || service.HasBreed(ctx, "hound", "") {
+		t.Fatalf("catalog replaced %d times, want the first catalog kept", repository.replaces)
+	}
+}
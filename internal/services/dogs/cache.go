@@ -0,0 +1,152 @@
+package dogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	models "go-platform/internal/models/dogs"
+	"go-platform/internal/models/outbox"
+	"go-platform/pkg/cache/redis"
+	"go-platform/pkg/config"
+)
+
+type cacheFamilies struct {
+	dog          redis.Family
+	dogsList     redis.Family
+	unknownBreed redis.Family
+	s3URL        redis.Family
+}
+
+func newCacheFamilies(cfg config.CacheConfig) cacheFamilies {
+	return cacheFamilies{
+		dog:          redis.Family{Name: "dog", TTL: cfg.DogTTL},
+		dogsList:     redis.Family{Name: "dogs_list", TTL: cfg.DogsListTTL},
+		unknownBreed: redis.Family{Name: "unknown_breed", NegativeErr: models.ErrBreedNotFound, NegativeTTL: cfg.NegativeTTL},
+		s3URL:        redis.Family{Name: "s3_url", TTL: cfg.S3URLTTL},
+	}
+}
+
+// cachedRepository caches by-ID lookups and per-breed list pages
+type cachedRepository struct {
+	Repository
+	cache    *redis.Cache
+	families cacheFamilies
+}
+
+// NewCachedRepository wraps the repository with a read-through cache
+func NewCachedRepository(repository Repository, cache *redis.Cache, cfg config.CacheConfig) Repository {
+	return &cachedRepository{Repository: repository, cache: cache, families: newCacheFamilies(cfg)}
+}
+
+func (r *cachedRepository) InsertDog(ctx context.Context, dog *models.Dog, outboxFn outbox.MessageFunc) (string, error) {
+	id, err := r.Repository.InsertDog(ctx, dog, outboxFn)
+	if err != nil {
+		return "", err
+	}
+
+	r.invalidateFirstPages(ctx, dog.Breed)
+
+	return id, nil
+}
+
+func (r *cachedRepository) InsertDogs(ctx context.Context, batch []*models.Dog, outboxFn outbox.BatchMessageFunc) ([]string, error) {
+	ids, err := r.Repository.InsertDogs(ctx, batch, outboxFn)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(batch) > 0 {
+		r.invalidateFirstPages(ctx, batch[0].Breed)
+	}
+
+	return ids, nil
+}
+
+func (r *cachedRepository) GetDogByID(ctx context.Context, id string) (*models.Dog, error) {
+	return redis.GetOrLoad(ctx, r.cache, r.families.dog, id, func(ctx context.Context) (*models.Dog, error) {
+		return r.Repository.GetDogByID(ctx, id)
+	})
+}
+
+func (r *cachedRepository) ListDogs(ctx context.Context, filter models.ListDogsFilter) (*models.DogsPage, error) {
+	// Time-range queries are ad-hoc and rarely repeated
+	if !filter.From.IsZero() || !filter.To.IsZero() {
+		return r.Repository.ListDogs(ctx, filter)
+	}
+
+	return redis.GetOrLoad(ctx, r.cache, r.families.dogsList, dogsListKey(filter), func(ctx context.Context) (*models.DogsPage, error) {
+		return r.Repository.ListDogs(ctx, filter)
+	})
+}
+
+// invalidateFirstPages drops the first pages so new images show up right away,
+// deeper pages are short-lived and expire on their own
+func (r *cachedRepository) invalidateFirstPages(ctx context.Context, breed string) {
+	r.cache.Delete(ctx, r.families.dogsList,
+		dogsListKey(models.ListDogsFilter{Breed: breed, Limit: defaultListLimit}),
+		dogsListKey(models.ListDogsFilter{Limit: defaultListLimit}),
+	)
+}
+
+func dogsListKey(filter models.ListDogsFilter) string {
+	return fmt.Sprintf("breed=%s|cursor=%s|limit=%d", filter.Breed, filter.Cursor, filter.Limit)
+}
+
+// cachedDogAPI remembers breeds unknown to the upstream
+type cachedDogAPI struct {
+	DogAPIClient
+	cache    *redis.Cache
+	families cacheFamilies
+}
+
+// NewCachedDogAPI wraps the dog API client with negative caching of unknown breeds
+func NewCachedDogAPI(dogAPI DogAPIClient, cache *redis.Cache, cfg config.CacheConfig) DogAPIClient {
+	return &cachedDogAPI{DogAPIClient: dogAPI, cache: cache, families: newCacheFamilies(cfg)}
+}
+
+func (c *cachedDogAPI) GetRandomDogImageByBreed(ctx context.Context, breed, subBreed string) (string, error) {
+	key := models.BreedPath(breed, subBreed)
+	if c.cache.IsNegative(ctx, c.families.unknownBreed, key) {
+		return "", models.ErrBreedNotFound
+	}
+
+	imageURL, err := c.DogAPIClient.GetRandomDogImageByBreed(ctx, breed, subBreed)
+	if errors.Is(err, models.ErrBreedNotFound) {
+		c.cache.SetNegative(ctx, c.families.unknownBreed, key)
+	}
+
+	return imageURL, err
+}
+
+func (c *cachedDogAPI) GetRandomDogImagesByBreed(ctx context.Context, breed, subBreed string, n int) ([]string, error) {
+	key := models.BreedPath(breed, subBreed)
+	if c.cache.IsNegative(ctx, c.families.unknownBreed, key) {
+		return nil, models.ErrBreedNotFound
+	}
+
+	imageURLs, err := c.DogAPIClient.GetRandomDogImagesByBreed(ctx, breed, subBreed, n)
+	if errors.Is(err, models.ErrBreedNotFound) {
+		c.cache.SetNegative(ctx, c.families.unknownBreed, key)
+	}
+
+	return imageURLs, err
+}
+
+// cachedClientS3 caches presigned object URLs, the family TTL must be shorter than their expiry
+type cachedClientS3 struct {
+	ClientS3
+	cache    *redis.Cache
+	families cacheFamilies
+}
+
+// NewCachedClientS3 wraps the S3 client with a cache of generated URLs
+func NewCachedClientS3(clientS3 ClientS3, cache *redis.Cache, cfg config.CacheConfig) ClientS3 {
+	return &cachedClientS3{ClientS3: clientS3, cache: cache, families: newCacheFamilies(cfg)}
+}
+
+func (c *cachedClientS3) GenerateURL(ctx context.Context, key string) (string, error) {
+	return redis.GetOrLoad(ctx, c.cache, c.families.s3URL, key, func(ctx context.Context) (string, error) {
+		return c.ClientS3.GenerateURL(ctx, key)
+	})
+}
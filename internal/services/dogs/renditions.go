@@ -0,0 +1,90 @@
+package dogs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	models "go-platform/internal/models/dogs"
+	"go-platform/internal/models/objects"
+	"go-platform/pkg/imaging"
+)
+
+const (
+	// probeSize must cover the image header; JPEG headers with EXIF and ICC profiles
+	// can take a few 64 KiB segments before the frame header
+	probeSize = 256 << 10
+	// maxDecodePixels bounds the memory taken by a decoded image, 4 bytes per pixel
+	maxDecodePixels  = 50_000_000
+	renditionQuality = 85
+)
+
+// rendition is a downscaled JPEG stored next to the original
+type rendition struct {
+	suffix string
+	size   int
+}
+
+var (
+	thumbnailRendition = rendition{suffix: "thumb", size: 200}
+	mediumRendition    = rendition{suffix: "medium", size: 800}
+)
+
+func renditionKey(imageKey string, r rendition) string {
+	return imageKey + "_" + r.suffix + ".jpg"
+}
+
+// createRenditions stores the thumbnail and medium renditions of the dog image and sets their keys.
+// The original is read back from S3, renditions already stored for an identical image are kept.
+func (s *DogsService) createRenditions(ctx context.Context, dog *models.Dog) error {
+	if dog.Width*dog.Height > maxDecodePixels {
+		return fmt.Errorf("image of %dx%d is too large to process", dog.Width, dog.Height)
+	}
+
+	dog.ThumbnailKey = renditionKey(dog.ImageKey, thumbnailRendition)
+	dog.MediumKey = renditionKey(dog.ImageKey, mediumRendition)
+
+	var missing []rendition
+	for _, r := range []rendition{thumbnailRendition, mediumRendition} {
+		_, err := s.clientS3.HeadObject(ctx, renditionKey(dog.ImageKey, r))
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, objects.ErrObjectNotFound) {
+			slog.WarnContext(ctx, "Failed to check rendition, creating it anyway", "key", renditionKey(dog.ImageKey, r), "error", err)
+		}
+		missing = append(missing, r)
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	body, _, err := s.clientS3.GetObject(ctx, dog.ImageKey)
+	if err != nil {
+		return fmt.Errorf("failed to read original image: %w", err)
+	}
+	defer body.Close()
+
+	img, err := imaging.Decode(body)
+	if err != nil {
+		return err
+	}
+
+	metadata := map[string]string{objects.MetadataBreed: dog.Breed}
+	for _, r := range missing {
+		var buf bytes.Buffer
+		if err := imaging.EncodeJPEG(&buf, imaging.Fit(img, r.size), renditionQuality); err != nil {
+			return err
+		}
+
+		key := renditionKey(dog.ImageKey, r)
+		if err := s.clientS3.PutObject(ctx, key, &buf, int64(buf.Len()), metadata); err != nil {
+			return fmt.Errorf("failed to upload %s rendition: %w", r.suffix, err)
+		}
+		slog.InfoContext(ctx, "Uploaded rendition to S3", "breed", dog.Breed, "key", key, "size", buf.Len())
+	}
+
+	return nil
+}
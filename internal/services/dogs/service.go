@@ -1,87 +1,420 @@
 package dogs
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	models "go-platform/internal/models/dogs"
+	"go-platform/internal/models/objects"
+	"go-platform/internal/models/outbox"
+	"go-platform/pkg/broker/nats"
+	"go-platform/pkg/config"
+	"go-platform/pkg/httpclient"
+	"go-platform/pkg/imaging"
+	"go-platform/pkg/utils/errs"
+	"io"
 	"log/slog"
+	"sync"
 
 	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
 )
 
 type DogAPIClient interface {
-	GetRandomDogImageByBreed(breed string) (string, error)
-	DownloadDogImage(imageURL string) ([]byte, error)
+	GetRandomDogImageByBreed(ctx context.Context, breed, subBreed string) (string, error)
+	GetRandomDogImagesByBreed(ctx context.Context, breed, subBreed string, n int) ([]string, error)
+	DownloadDogImage(ctx context.Context, imageURL string) (io.ReadCloser, int64, error)
 }
 type ClientS3 interface {
-	PutObject(ctx context.Context, key string, data []byte) (err error)
-	GenerateURL(key string) string
+	PutObject(ctx context.Context, key string, body io.Reader, size int64, metadata map[string]string) error
+	GetObject(ctx context.Context, key string) (io.ReadCloser, *objects.ObjectInfo, error)
+	HeadObject(ctx context.Context, key string) (*objects.ObjectInfo, error)
+	DeleteObject(ctx context.Context, key string) error
+	ListObjects(ctx context.Context, prefix, token string, limit int) (*objects.ObjectsPage, error)
+	CopyObject(ctx context.Context, srcKey, dstKey string) error
+	PutContentAddressed(ctx context.Context, prefix string, body io.Reader, size int64, metadata map[string]string) (*objects.ObjectInfo, error)
+	GenerateURL(ctx context.Context, key string) (string, error)
+	PresignPutURL(ctx context.Context, key, contentType string) (*objects.PresignedRequest, error)
 }
 
 type Repository interface {
 	// return string due to clickhouse dont have auto increment and
 	// we should use uuid for simple row
-	InsertDog(ctx context.Context, dog *models.Dog) (string, error)
+	InsertDog(ctx context.Context, dog *models.Dog, outboxFn outbox.MessageFunc) (string, error)
+	InsertDogs(ctx context.Context, dogs []*models.Dog, outboxFn outbox.BatchMessageFunc) ([]string, error)
+	GetDogByID(ctx context.Context, id string) (*models.Dog, error)
+	ListDogs(ctx context.Context, filter models.ListDogsFilter) (*models.DogsPage, error)
+}
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+
+	// imageKeyPrefix holds archived images under their breed and content hash
+	imageKeyPrefix = "dogs"
+	// uploadKeyPrefix keeps photos uploaded by clients apart from archived images
+	uploadKeyPrefix = "uploads"
+)
+
+// BreedCatalog tells known breeds and sub-breeds apart from typos before the dog API is called
+type BreedCatalog interface {
+	HasBreed(ctx context.Context, breed, subBreed string) bool
 }
 
 type DogsService struct {
-	dogAPI     DogAPIClient
-	clientS3   ClientS3
-	repository Repository
+	dogAPI           DogAPIClient
+	clientS3         ClientS3
+	repository       Repository
+	breeds           BreedCatalog
+	batchConcurrency int
+	batchMaxSize     int
 }
 
-func NewDogsService(dogAPI DogAPIClient, clientS3 ClientS3, repository Repository) *DogsService {
+func NewDogsService(dogAPI DogAPIClient, clientS3 ClientS3, repository Repository, breeds BreedCatalog, cfg config.DogAPIConfig) *DogsService {
 	return &DogsService{
-		dogAPI:     dogAPI,
-		clientS3:   clientS3,
-		repository: repository,
+		dogAPI:           dogAPI,
+		clientS3:         clientS3,
+		repository:       repository,
+		breeds:           breeds,
+		batchConcurrency: max(cfg.BatchConcurrency, 1),
+		batchMaxSize:     max(cfg.BatchMaxSize, 1),
+	}
+}
+
+// ValidateBreed returns ErrBreedNotFound for a breed or sub-breed missing from the catalog
+func (s *DogsService) ValidateBreed(ctx context.Context, breed, subBreed string) error {
+	if !s.breeds.HasBreed(ctx, breed, subBreed) {
+		slog.InfoContext(ctx, "Unknown breed rejected", "breed", breed, "sub_breed", subBreed)
+		return models.ErrBreedNotFound
 	}
+
+	return nil
 }
 
-// GetRandomDogImage gets a random dog image url for a breed and returns the image url
-func (s *DogsService) GetRandomDogImage(ctx context.Context, breed string) (string, error) {
-	slog.Info("Starting dog image retrieval", "breed", breed)
+// GetRandomDogImage gets a random dog image for a breed and optional sub-breed,
+// archives it with its renditions and returns the saved dog
+func (s *DogsService) GetRandomDogImage(ctx context.Context, breed, subBreed string) (*models.Dog, error) {
+	if err := s.ValidateBreed(ctx, breed, subBreed); err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Starting dog image retrieval", "breed", breed, "sub_breed", subBreed)
 
 	// First get the image URL
-	imageURL, err := s.dogAPI.GetRandomDogImageByBreed(breed)
+	imageURL, err := s.dogAPI.GetRandomDogImageByBreed(ctx, breed, subBreed)
 	if err != nil {
-		slog.Error("Failed to get image URL", "breed", breed, "error", err)
-		return "", fmt.Errorf("failed to get image URL: %w", err)
+		logUpstreamError(ctx, "Failed to get image URL", err, "breed", breed)
+		return nil, fmt.Errorf("failed to get image URL: %w", err)
 	}
-	slog.Info("Got image URL", "breed", breed, "url", imageURL)
+	slog.InfoContext(ctx, "Got image URL", "breed", breed, "url", imageURL)
 
-	// Then download the actual image
-	imageBytes, err := s.dogAPI.DownloadDogImage(imageURL)
+	image, err := s.archiveImage(ctx, breed, subBreed, imageURL)
 	if err != nil {
-		slog.Error("Failed to download image", "breed", breed, "url", imageURL, "error", err)
-		return imageURL, fmt.Errorf("failed to download image: %w", err)
+		return nil, err
 	}
-	slog.Info("Downloaded image", "breed", breed, "size", len(imageBytes))
+	dog := image.dog
+	slog.InfoContext(ctx, "Dog image retrieval completed", "breed", breed, "s3_url", dog.ImageURL)
 
-	// Then upload the image to S3
-	imageKey := fmt.Sprintf("dogs/%s/%s", breed, uuid.New().String())
-	err = s.clientS3.PutObject(ctx, imageKey, imageBytes)
+	// the event is written to the outbox with the row and relayed to the broker later,
+	// an image stored before returns the existing row and emits no event
+	id, err := s.repository.InsertDog(ctx, dog, func(id string) (*outbox.Message, error) {
+		return s.newImageArchivedMessage(ctx, id, dog, image.size)
+	})
 	if err != nil {
-		slog.Error("Failed to upload to S3", "breed", breed, "key", imageKey, "error", err)
-		return imageURL, fmt.Errorf("failed to upload to S3: %w", err)
+		slog.ErrorContext(ctx, "Failed to insert dog into database", "breed", breed, "error", err)
+		return nil, fmt.Errorf("failed to insert dog into database: %w", err)
+	}
+	dog.ID = id
+
+	// an identical image stored before may live under another breed key
+	if err := s.resolveImageURL(ctx, dog); err != nil {
+		return nil, err
 	}
-	slog.Info("Uploaded to S3", "breed", breed, "key", imageKey)
 
-	// Generate and return the S3 URL
-	s3URL := s.clientS3.GenerateURL(imageKey)
-	slog.Info("Dog image retrieval completed", "breed", breed, "s3_url", s3URL)
+	return dog, nil
+}
+
+// GetRandomDogImages ingests n random images of a breed or sub-breed. Downloads and uploads run in parallel
+// up to the configured concurrency and successful images are saved with a single insert.
+// A failed item does not fail the batch, it is reported in its result instead.
+// onResult, if set, is called once per item as soon as its upload completes or fails.
+func (s *DogsService) GetRandomDogImages(ctx context.Context, breed, subBreed string, n int, onResult func(models.BatchImageResult)) (*models.BatchImagesResponse, error) {
+	n = min(max(n, 1), s.batchMaxSize)
+	if err := s.ValidateBreed(ctx, breed, subBreed); err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "Starting batch dog image retrieval", "breed", breed, "sub_breed", subBreed, "count", n)
+
+	imageURLs, err := s.dogAPI.GetRandomDogImagesByBreed(ctx, breed, subBreed, n)
+	if err != nil {
+		logUpstreamError(ctx, "Failed to get image URLs", err, "breed", breed)
+		return nil, fmt.Errorf("failed to get image URLs: %w", err)
+	}
+
+	results := make([]models.BatchImageResult, len(imageURLs))
+	images := make([]*archivedImage, len(imageURLs))
+
+	var mu sync.Mutex
+	var g errgroup.Group
+	g.SetLimit(s.batchConcurrency)
+	for i, imageURL := range imageURLs {
+		g.Go(func() error {
+			result := models.BatchImageResult{Index: i, SourceURL: imageURL}
+
+			image, err := s.archiveImage(ctx, breed, subBreed, imageURL)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.ImageURL = image.dog.ImageURL
+				result.ThumbnailURL = image.dog.ThumbnailURL
+				result.MediumURL = image.dog.MediumURL
+				images[i] = image
+			}
+			results[i] = result
+
+			if onResult != nil {
+				mu.Lock()
+				onResult(result)
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	// a cancelled batch is not saved, uploaded objects are found again by content hash on a retry
+	if err := ctx.Err(); err != nil {
+		slog.WarnContext(ctx, "Batch dog image retrieval cancelled", "breed", breed, "reason", httpclient.CancellationReason(ctx, err))
+		return nil, err
+	}
+
+	// the same picture may be returned more than once, it is saved once
+	var (
+		batch     []*models.Dog
+		saved     []*archivedImage
+		succeeded int
+	)
+	seen := make(map[string]struct{}, len(images))
+	for _, image := range images {
+		if image == nil {
+			continue
+		}
+		succeeded++
+		if _, ok := seen[image.dog.ContentHash]; ok {
+			continue
+		}
+		seen[image.dog.ContentHash] = struct{}{}
+		batch = append(batch, image.dog)
+		saved = append(saved, image)
+	}
+
+	if len(batch) > 0 {
+		_, err = s.repository.InsertDogs(ctx, batch, func(index int, id string) (*outbox.Message, error) {
+			return s.newImageArchivedMessage(ctx, id, batch[index], saved[index].size)
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to insert dogs into database", "breed", breed, "count", len(batch), "error", err)
+			return nil, fmt.Errorf("failed to insert dogs into database: %w", err)
+		}
+	}
+
+	resp := &models.BatchImagesResponse{
+		Breed:     breed,
+		SubBreed:  subBreed,
+		Requested: n,
+		Succeeded: succeeded,
+		Failed:    len(results) - succeeded,
+		Results:   results,
+	}
+	slog.InfoContext(ctx, "Batch dog image retrieval completed", "breed", breed, "succeeded", resp.Succeeded, "failed", resp.Failed)
+
+	return resp, nil
+}
+
+// archivedImage is an image uploaded to S3 with its renditions, not saved to the repository yet
+type archivedImage struct {
+	dog  *models.Dog
+	size int
+}
+
+// archiveImage validates the image, streams it from the dog API to S3 under a content-addressed key
+// and stores its renditions. An identical image already stored is kept.
+// The original is never held in memory as a whole, only its decoded pixels while renditions are made.
+func (s *DogsService) archiveImage(ctx context.Context, breed, subBreed, imageURL string) (*archivedImage, error) {
+	body, size, err := s.dogAPI.DownloadDogImage(ctx, imageURL)
+	if err != nil {
+		logUpstreamError(ctx, "Failed to download image", err, "breed", breed, "url", imageURL)
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	defer body.Close()
+
+	// the header is peeked so the stream is still uploaded from its first byte
+	stream := bufio.NewReaderSize(body, probeSize)
+	head, err := stream.Peek(probeSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	probe, err := imaging.Probe(bytes.NewReader(head))
+	if err != nil {
+		slog.ErrorContext(ctx, "Invalid image", "breed", breed, "url", imageURL, "error", err)
+		return nil, errs.Errorf(errs.UpstreamUnavailable, "invalid image: %w", err)
+	}
+
+	metadata := map[string]string{
+		objects.MetadataBreed:     breed,
+		objects.MetadataSourceURL: imageURL,
+	}
+	info, err := s.clientS3.PutContentAddressed(ctx, imageKeyPrefix+"/"+models.BreedPath(breed, subBreed), stream, size, metadata)
+	if err != nil {
+		logUpstreamError(ctx, "Failed to upload to S3", err, "breed", breed, "url", imageURL)
+		return nil, fmt.Errorf("failed to upload to S3: %w", err)
+	}
+	slog.InfoContext(ctx, "Uploaded to S3", "breed", breed, "key", info.Key, "size", info.Size)
 
-	// save into local database
 	dog := &models.Dog{
-		Breed:    breed,
-		ImageURL: s3URL,
+		Breed:       breed,
+		SubBreed:    subBreed,
+		ImageKey:    info.Key,
+		ContentHash: info.ContentHash,
+		Width:       probe.Width,
+		Height:      probe.Height,
+		MIMEType:    probe.MIMEType,
+	}
+	if err := s.createRenditions(ctx, dog); err != nil {
+		slog.ErrorContext(ctx, "Failed to create renditions", "breed", breed, "key", info.Key, "error", err)
+		return nil, fmt.Errorf("failed to create renditions: %w", err)
+	}
+
+	if err := s.resolveImageURL(ctx, dog); err != nil {
+		return nil, err
+	}
+
+	return &archivedImage{dog: dog, size: int(info.Size)}, nil
+}
+
+// GetDog returns a previously archived dog image by its ID
+func (s *DogsService) GetDog(ctx context.Context, id string) (*models.Dog, error) {
+	dog, err := s.repository.GetDogByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dog: %w", err)
+	}
+
+	// the repository may hand out a shared cached value
+	served := *dog
+	if err := s.resolveImageURL(ctx, &served); err != nil {
+		return nil, err
+	}
+
+	return &served, nil
+}
+
+// ListDogs returns a page of archived dog images, newest first
+func (s *DogsService) ListDogs(ctx context.Context, filter models.ListDogsFilter) (*models.DogsPage, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+
+	page, err := s.repository.ListDogs(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dogs: %w", err)
+	}
+
+	// the repository may hand out a shared cached value
+	served := &models.DogsPage{Dogs: make([]models.Dog, len(page.Dogs)), NextCursor: page.NextCursor}
+	copy(served.Dogs, page.Dogs)
+	for i := range served.Dogs {
+		if err := s.resolveImageURL(ctx, &served.Dogs[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return served, nil
+}
+
+// CreateUploadURL issues a presigned PUT request so a client can upload its own photo of the breed directly to S3
+func (s *DogsService) CreateUploadURL(ctx context.Context, breed, contentType string) (*models.UploadURLResponse, error) {
+	if err := s.ValidateBreed(ctx, breed, ""); err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("%s/%s/%s", uploadKeyPrefix, breed, uuid.New().String())
+
+	req, err := s.clientS3.PresignPutURL(ctx, key, contentType)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to presign upload", "breed", breed, "key", key, "error", err)
+		return nil, fmt.Errorf("failed to presign upload: %w", err)
+	}
+	slog.InfoContext(ctx, "Upload URL issued", "breed", breed, "key", key, "expires_at", req.ExpiresAt)
+
+	return &models.UploadURLResponse{
+		Key:       key,
+		URL:       req.URL,
+		Method:    req.Method,
+		Headers:   req.Headers,
+		ExpiresAt: req.ExpiresAt,
+	}, nil
+}
+
+// logUpstreamError logs a failed upstream call. A call that failed because the caller went away,
+// its deadline passed or the service shuts down is logged as a cancellation with its reason.
+func logUpstreamError(ctx context.Context, msg string, err error, args ...any) {
+	if reason := httpclient.CancellationReason(ctx, err); reason != "" {
+		slog.WarnContext(ctx, msg+": cancelled", append(args, "reason", reason, "error", err)...)
+		return
 	}
+	slog.ErrorContext(ctx, msg, append(args, "error", err)...)
+}
+
+// resolveImageURL presigns the URLs of a dog image and its renditions stored by key,
+// dogs stored before keys keep their URL and have no renditions
+func (s *DogsService) resolveImageURL(ctx context.Context, dog *models.Dog) error {
+	for _, target := range []struct {
+		key string
+		url *string
+	}{
+		{dog.ImageKey, &dog.ImageURL},
+		{dog.ThumbnailKey, &dog.ThumbnailURL},
+		{dog.MediumKey, &dog.MediumURL},
+	} {
+		if target.key == "" {
+			continue
+		}
+
+		url, err := s.clientS3.GenerateURL(ctx, target.key)
+		if err != nil {
+			return fmt.Errorf("failed to generate image URL: %w", err)
+		}
+		*target.url = url
+	}
+
+	return nil
+}
+
+// newImageArchivedMessage builds the outbox message of an archived image,
+// the deprecated URL of the v1 event is presigned from its key
+func (s *DogsService) newImageArchivedMessage(ctx context.Context, id string, dog *models.Dog, size int) (*outbox.Message, error) {
+	url, err := s.clientS3.GenerateURL(ctx, dog.ImageKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate image URL: %w", err)
+	}
+
+	return newOutboxMessage(ctx, models.NewImageArchivedEvent(id, dog, dog.ImageKey, url, size))
+}
 
-	_, err = s.repository.InsertDog(ctx, dog)
+func newOutboxMessage(ctx context.Context, event nats.Event) (*outbox.Message, error) {
+	msg, err := nats.NewMessage(ctx, event)
 	if err != nil {
-		slog.Error("Failed to insert dog into database", "breed", breed, "error", err)
-		return imageURL, fmt.Errorf("failed to insert dog into database: %w", err)
+		return nil, err
 	}
 
-	return s3URL, nil
+	return &outbox.Message{
+		ID:        msg.ID,
+		EventType: msg.EventType,
+		Payload:   msg.Data,
+		Headers:   msg.Headers,
+	}, nil
 }
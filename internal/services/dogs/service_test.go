@@ -0,0 +1,296 @@
+package dogs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	models "go-platform/internal/models/dogs"
+	"go-platform/internal/models/objects"
+	"go-platform/internal/models/outbox"
+	"go-platform/pkg/config"
+)
+
+var errNotImplemented = errors.New("not implemented")
+
+// memoryS3 keeps objects in a map, content-addressed keys are built like the S3 client does
+type memoryS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newMemoryS3() *memoryS3 {
+	return &memoryS3{objects: make(map[string][]byte)}
+}
+
+func (m *memoryS3) PutObject(_ context.Context, key string, body io.Reader, _ int64, _ map[string]string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = data
+	return nil
+}
+
+func (m *memoryS3) GetObject(_ context.Context, key string) (io.ReadCloser, *objects.ObjectInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, nil, objects.ErrObjectNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), &objects.ObjectInfo{Key: key, Size: int64(len(data))}, nil
+}
+
+func (m *memoryS3) HeadObject(_ context.Context, key string) (*objects.ObjectInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, objects.ErrObjectNotFound
+	}
+	return &objects.ObjectInfo{Key: key, Size: int64(len(data))}, nil
+}
+
+func (m *memoryS3) PutContentAddressed(ctx context.Context, prefix string, body io.Reader, size int64, metadata map[string]string) (*objects.ObjectInfo, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	key := prefix + "/" + hash
+	if err := m.PutObject(ctx, key, bytes.NewReader(data), size, metadata); err != nil {
+		return nil, err
+	}
+	return &objects.ObjectInfo{Key: key, Size: int64(len(data)), ContentHash: hash}, nil
+}
+
+func (m *memoryS3) GenerateURL(_ context.Context, key string) (string, error) {
+	return "https://s3.test/" + key, nil
+}
+
+func (m *memoryS3) DeleteObject(context.Context, string) error { return errNotImplemented }
+
+func (m *memoryS3) ListObjects(context.Context, string, string, int) (*objects.ObjectsPage, error) {
+	return nil, errNotImplemented
+}
+
+func (m *memoryS3) CopyObject(context.Context, string, string) error { return errNotImplemented }
+
+func (m *memoryS3) PresignPutURL(context.Context, string, string) (*objects.PresignedRequest, error) {
+	return nil, errNotImplemented
+}
+
+// stubDogAPI serves the images of its map, URLs missing from it fail to download
+type stubDogAPI struct {
+	urls   []string
+	images map[string][]byte
+}
+
+func (a *stubDogAPI) GetRandomDogImageByBreed(context.Context, string, string) (string, error) {
+	return a.urls[0], nil
+}
+
+func (a *stubDogAPI) GetRandomDogImagesByBreed(_ context.Context, _, _ string, n int) ([]string, error) {
+	return a.urls[:min(n, len(a.urls))], nil
+}
+
+func (a *stubDogAPI) DownloadDogImage(_ context.Context, imageURL string) (io.ReadCloser, int64, error) {
+	data, ok := a.images[imageURL]
+	if !ok {
+		return nil, 0, errors.New("connection reset")
+	}
+	return io.NopCloser(bytes.NewReader(data)), int64(len(data)), nil
+}
+
+// memoryRepository stores dogs with the outbox messages written with them
+type memoryRepository struct {
+	dogs     []*models.Dog
+	messages []*outbox.Message
+	inserts  int
+}
+
+func (r *memoryRepository) InsertDog(_ context.Context, dog *models.Dog, outboxFn outbox.MessageFunc) (string, error) {
+	ids, err := r.insert([]*models.Dog{dog}, func(_ int, id string) (*outbox.Message, error) { return outboxFn(id) })
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+func (r *memoryRepository) InsertDogs(_ context.Context, dogs []*models.Dog, outboxFn outbox.BatchMessageFunc) ([]string, error) {
+	return r.insert(dogs, outboxFn)
+}
+
+func (r *memoryRepository) insert(dogs []*models.Dog, outboxFn outbox.BatchMessageFunc) ([]string, error) {
+	r.inserts++
+	ids := make([]string, len(dogs))
+	for i, dog := range dogs {
+		ids[i] = strconv.Itoa(len(r.dogs) + 1)
+		msg, err := outboxFn(i, ids[i])
+		if err != nil {
+			return nil, err
+		}
+		stored := *dog
+		stored.ID = ids[i]
+		r.dogs = append(r.dogs, &stored)
+		r.messages = append(r.messages, msg)
+	}
+	return ids, nil
+}
+
+func (r *memoryRepository) GetDogByID(context.Context, string) (*models.Dog, error) {
+	return nil, errNotImplemented
+}
+
+func (r *memoryRepository) ListDogs(context.Context, models.ListDogsFilter) (*models.DogsPage, error) {
+	return nil, errNotImplemented
+}
+
+// knownBreeds accepts every breed
+type knownBreeds struct{}
+
+func (knownBreeds) HasBreed(context.Context, string, string) bool { return true }
+
+// pngImage encodes a small image filled with shade, images of different shades hash differently
+func pngImage(t *testing.T, shade uint8) []byte {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, 4, 4))
+	for i := range img.Pix {
+		img.Pix[i] = shade
+	}
+	img.Set(0, 0, color.Gray{Y: shade + 1})
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func newTestService(api *stubDogAPI) (*DogsService, *memoryS3, *memoryRepository) {
+	s3 := newMemoryS3()
+	repository := &memoryRepository{}
+	return NewDogsService(api, s3, repository, knownBreeds{}, config.DogAPIConfig{BatchConcurrency: 2, BatchMaxSize: 10}), s3, repository
+}
+
+func TestGetRandomDogImagesReportsFailedItems(t *testing.T) {
+	api := &stubDogAPI{
+		urls: []string{"https://dog.test/1.png", "https://dog.test/missing.png", "https://dog.test/2.png"},
+		images: map[string][]byte{
+			"https://dog.test/1.png": pngImage(t, 10),
+			"https://dog.test/2.png": pngImage(t, 20),
+		},
+	}
+	service, _, repository := newTestService(api)
+
+	var streamed []models.BatchImageResult
+	resp, err := service.GetRandomDogImages(context.Background(), "hound", "", 3, func(result models.BatchImageResult) {
+		streamed = append(streamed, result)
+	})
+	if err != nil {
+		t.Fatalf("GetRandomDogImages() error = %v", err)
+	}
+
+	if resp.Requested != 3 || resp.Succeeded != 2 || resp.Failed != 1 {
+		t.Fatalf("GetRandomDogImages() requested/succeeded/failed = %d/%d/%d, want 3/2/1", resp.Requested, resp.Succeeded, resp.Failed)
+	}
+	if len(streamed) != 3 {
+		t.Fatalf("onResult called %d times, want 3", len(streamed))
+	}
+	for i, result := range resp.Results {
+		if result.Index != i || result.SourceURL != api.urls[i] {
+			t.Fatalf("result %d = %+v, want index %d of %s", i, result, i, api.urls[i])
+		}
+		failed := api.images[result.SourceURL] == nil
+		if failed != (result.Error != "") || failed != (result.ImageURL == "") {
+			t.Fatalf("result %d = %+v, want failed %v", i, result, failed)
+		}
+	}
+	// successful images are saved with a single insert and one event each
+	if repository.inserts != 1 || len(repository.dogs) != 2 || len(repository.messages) != 2 {
+		t.Fatalf("inserts = %d with %d dogs and %d events, want 1 with 2 and 2", repository.inserts, len(repository.dogs), len(repository.messages))
+	}
+	for _, dog := range repository.dogs {
+		if !strings.HasPrefix(dog.ImageKey, "dogs/hound/") || dog.ThumbnailKey == "" || dog.MediumKey == "" {
+			t.Fatalf("saved dog = %+v, want an image under dogs/hound/ with renditions", dog)
+		}
+	}
+}
+
+func TestGetRandomDogImagesSavesIdenticalImagesOnce(t *testing.T) {
+	same := pngImage(t, 30)
+	api := &stubDogAPI{
+		urls: []string{"https://dog.test/a.png", "https://dog.test/b.png", "https://dog.test/c.png"},
+		images: map[string][]byte{
+			"https://dog.test/a.png": same,
+			"https://dog.test/b.png": same,
+			"https://dog.test/c.png": pngImage(t, 40),
+		},
+	}
+	service, s3, repository := newTestService(api)
+
+	resp, err := service.GetRandomDogImages(context.Background(), "hound", "", 3, nil)
+	if err != nil {
+		t.Fatalf("GetRandomDogImages() error = %v", err)
+	}
+
+	// both copies are reported as archived, they point to the same object
+	if resp.Succeeded != 3 || resp.Failed != 0 {
+		t.Fatalf("GetRandomDogImages() succeeded/failed = %d/%d, want 3/0", resp.Succeeded, resp.Failed)
+	}
+	if resp.Results[0].ImageURL != resp.Results[1].ImageURL {
+		t.Fatalf("identical images served from %q and %q, want one URL", resp.Results[0].ImageURL, resp.Results[1].ImageURL)
+	}
+	if len(repository.dogs) != 2 || len(repository.messages) != 2 {
+		t.Fatalf("saved %d dogs with %d events, want 2 and 2", len(repository.dogs), len(repository.messages))
+	}
+	if repository.dogs[0].ContentHash == repository.dogs[1].ContentHash {
+		t.Fatalf("saved dogs share content hash %s", repository.dogs[0].ContentHash)
+	}
+	// two originals and their renditions
+	if len(s3.objects) != 6 {
+		t.Fatalf("stored %d objects, want 6", len(s3.objects))
+	}
+}
+
+func TestGetRandomDogImageKeepsSubBreed(t *testing.T) {
+	api := &stubDogAPI{
+		urls:   []string{"https://dog.test/afghan.png"},
+		images: map[string][]byte{"https://dog.test/afghan.png": pngImage(t, 50)},
+	}
+	service, _, repository := newTestService(api)
+
+	dog, err := service.GetRandomDogImage(context.Background(), "hound", "afghan")
+	if err != nil {
+		t.Fatalf("GetRandomDogImage() error = %v", err)
+	}
+
+	if dog.SubBreed != "afghan" || !strings.HasPrefix(dog.ImageKey, "dogs/hound/afghan/") {
+		t.Fatalf("GetRandomDogImage() = %+v, want sub-breed afghan under dogs/hound/afghan/", dog)
+	}
+	if len(repository.messages) != 1 {
+		t.Fatalf("saved %d events, want 1", len(repository.messages))
+	}
+	var event models.ImageArchivedEvent
+	if err := json.Unmarshal(repository.messages[0].Payload, &event); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if event.Breed != "hound" || event.SubBreed != "afghan" || event.S3Key != dog.ImageKey {
+		t.Fatalf("event = %+v, want hound/afghan at %s", event, dog.ImageKey)
+	}
+}
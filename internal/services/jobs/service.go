@@ -0,0 +1,227 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"go-platform/internal/models/dogs"
+	"go-platform/internal/models/jobs"
+	"go-platform/pkg/config"
+	"go-platform/pkg/requestid"
+	"go-platform/pkg/tracer"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Repository interface {
+	SaveJob(ctx context.Context, job *jobs.Job) error
+	GetJob(ctx context.Context, id string) (*jobs.Job, error)
+	ListUnfinishedJobs(ctx context.Context, instance string) ([]*jobs.Job, error)
+}
+
+type DogsService interface {
+	ValidateBreed(ctx context.Context, breed, subBreed string) error
+	GetRandomDogImage(ctx context.Context, breed, subBreed string) (*dogs.Dog, error)
+	GetDog(ctx context.Context, id string) (*dogs.Dog, error)
+}
+
+// JobsService runs dog image ingestion on a bounded worker pool.
+// Submit never blocks: when the queue is full the job is rejected.
+// The queue lives in memory, jobs left unfinished by a previous run of the instance are failed on start.
+type JobsService struct {
+	dogsService DogsService
+	repository  Repository
+	queue       chan *jobs.Job
+	workers     int
+	timeout     time.Duration
+	instance    string
+}
+
+func NewJobsService(dogsService DogsService, repository Repository, cfg config.JobsConfig) *JobsService {
+	instance := cfg.Instance
+	if instance == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			slog.Warn("Failed to get hostname, jobs use the default instance", "error", err)
+			hostname = "default"
+		}
+		instance = hostname
+	}
+
+	return &JobsService{
+		dogsService: dogsService,
+		repository:  repository,
+		queue:       make(chan *jobs.Job, cfg.QueueSize),
+		workers:     cfg.Workers,
+		timeout:     cfg.Timeout,
+		instance:    instance,
+	}
+}
+
+// SubmitDogImage queues ingestion of a random image for the breed or sub-breed and returns the job right away
+func (s *JobsService) SubmitDogImage(ctx context.Context, breed, subBreed string) (*jobs.Job, error) {
+	// an unknown breed is rejected now instead of failing in the worker
+	if err := s.dogsService.ValidateBreed(ctx, breed, subBreed); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	job := &jobs.Job{
+		ID:        uuid.New().String(),
+		Breed:     breed,
+		SubBreed:  subBreed,
+		Status:    jobs.StatusQueued,
+		RequestID: requestid.FromContext(ctx),
+		Instance:  s.instance,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repository.SaveJob(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to save job: %w", err)
+	}
+
+	// the worker updates its own copy, the caller reads the returned job while it runs
+	queued := *job
+	select {
+	case s.queue <- &queued:
+	default:
+		s.finish(ctx, job, "", jobs.ErrQueueFull)
+		return nil, jobs.ErrQueueFull
+	}
+
+	slog.InfoContext(ctx, "Job queued", "job_id", job.ID, "breed", breed, "sub_breed", subBreed)
+	return job, nil
+}
+
+// GetJob returns the current job state. The image URL of a succeeded job is presigned now,
+// the job outlives the expiry of a URL presigned when it finished.
+func (s *JobsService) GetJob(ctx context.Context, id string) (*jobs.Job, error) {
+	job, err := s.repository.GetJob(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	if job.DogID != "" {
+		dog, err := s.dogsService.GetDog(ctx, job.DogID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job image: %w", err)
+		}
+		job.ImageURL = dog.ImageURL
+	}
+
+	return job, nil
+}
+
+// Run starts the workers and blocks until ctx is cancelled.
+// Jobs still queued at shutdown are marked failed.
+func (s *JobsService) Run(ctx context.Context) {
+	s.failUnfinished(ctx)
+
+	slog.InfoContext(ctx, "Starting job workers", "workers", s.workers, "queue_size", cap(s.queue), "instance", s.instance)
+
+	var wg sync.WaitGroup
+	for range s.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-s.queue:
+					s.process(ctx, job)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// ctx is already cancelled, statuses are saved with a fresh one
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for {
+		select {
+		case job := <-s.queue:
+			s.finish(shutdownCtx, job, "", fmt.Errorf("server is shutting down"))
+		default:
+			slog.InfoContext(ctx, "Stopping job workers")
+			return
+		}
+	}
+}
+
+// failUnfinished marks failed the jobs a previous run of the instance queued but never finished,
+// their queue was lost with the process
+func (s *JobsService) failUnfinished(ctx context.Context) {
+	unfinished, err := s.repository.ListUnfinishedJobs(ctx, s.instance)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to list unfinished jobs", "instance", s.instance, "error", err)
+		return
+	}
+
+	for _, job := range unfinished {
+		s.finish(ctx, job, "", fmt.Errorf("server restarted before the job finished"))
+	}
+}
+
+func (s *JobsService) process(ctx context.Context, job *jobs.Job) {
+	if job.RequestID != "" {
+		ctx = requestid.NewContext(ctx, job.RequestID)
+	}
+
+	// every job is a trace of its own, the request that queued it has already been answered
+	ctx, span := tracer.StartSpan(ctx, "process job",
+		trace.WithNewRoot(),
+		trace.WithAttributes(
+			attribute.String("job.id", job.ID),
+			attribute.String("job.breed", job.Breed),
+			attribute.String("job.sub_breed", job.SubBreed),
+			attribute.String("request_id", job.RequestID),
+		),
+	)
+	defer span.End()
+
+	job.Status = jobs.StatusRunning
+	job.UpdatedAt = time.Now()
+	if err := s.repository.SaveJob(ctx, job); err != nil {
+		slog.ErrorContext(ctx, "Failed to save job", "job_id", job.ID, "error", err)
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	dog, err := s.dogsService.GetRandomDogImage(jobCtx, job.Breed, job.SubBreed)
+	var dogID string
+	if err == nil {
+		dogID = dog.ID
+	} else {
+		tracer.SetSpanError(span, err)
+	}
+
+	// the result must be saved even if shutdown cancelled the job
+	s.finish(context.WithoutCancel(ctx), job, dogID, err)
+}
+
+func (s *JobsService) finish(ctx context.Context, job *jobs.Job, dogID string, err error) {
+	if err != nil {
+		job.Status = jobs.StatusFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = jobs.StatusSucceeded
+		job.DogID = dogID
+	}
+	job.UpdatedAt = time.Now()
+
+	if err := s.repository.SaveJob(ctx, job); err != nil {
+		slog.ErrorContext(ctx, "Failed to save job", "job_id", job.ID, "error", err)
+	}
+
+	slog.InfoContext(ctx, "Job finished", "job_id", job.ID, "breed", job.Breed, "sub_breed", job.SubBreed, "status", job.Status)
+}
@@ -0,0 +1,172 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go-platform/internal/models/dogs"
+	"go-platform/internal/models/jobs"
+	"go-platform/pkg/config"
+)
+
+// memoryRepository stores copies of jobs, like the Redis repository does
+type memoryRepository struct {
+	mu   sync.Mutex
+	jobs map[string]jobs.Job
+}
+
+func (r *memoryRepository) SaveJob(_ context.Context, job *jobs.Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jobs[job.ID] = *job
+	return nil
+}
+
+func (r *memoryRepository) GetJob(_ context.Context, id string) (*jobs.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, jobs.ErrJobNotFound
+	}
+	return &job, nil
+}
+
+func (r *memoryRepository) ListUnfinishedJobs(_ context.Context, instance string) ([]*jobs.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unfinished []*jobs.Job
+	for _, job := range r.jobs {
+		if job.Instance == instance && !job.Status.Finished() {
+			unfinished = append(unfinished, &job)
+		}
+	}
+	return unfinished, nil
+}
+
+// stubDogs archives every image as dog 1 after delay
+type stubDogs struct {
+	delay time.Duration
+}
+
+func (stubDogs) ValidateBreed(context.Context, string, string) error { return nil }
+
+func (d stubDogs) GetRandomDogImage(ctx context.Context, breed, subBreed string) (*dogs.Dog, error) {
+	select {
+	case <-time.After(d.delay):
+		return &dogs.Dog{ID: "1", Breed: breed, SubBreed: subBreed}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (stubDogs) GetDog(_ context.Context, id string) (*dogs.Dog, error) {
+	return &dogs.Dog{ID: id, ImageURL: "https://s3.local/dogs/" + id}, nil
+}
+
+func newTestService(t *testing.T, repository *memoryRepository, cfg config.JobsConfig) *JobsService {
+	t.Helper()
+
+	service := NewJobsService(stubDogs{delay: 10 * time.Millisecond}, repository, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		service.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return service
+}
+
+// TestSubmitAndPoll reads the submitted jobs while the workers process them, run it with -race
+func TestSubmitAndPoll(t *testing.T) {
+	repository := &memoryRepository{jobs: map[string]jobs.Job{}}
+	service := newTestService(t, repository, config.JobsConfig{Workers: 2, QueueSize: 10, Timeout: time.Second, Instance: "test"})
+	ctx := context.Background()
+
+	var submitted []*jobs.Job
+	for range 5 {
+		job, err := service.SubmitDogImage(ctx, "hound", "afghan")
+		if err != nil {
+			t.Fatalf("SubmitDogImage() error = %v", err)
+		}
+		// a worker picks the job up while the handler builds the 202 response from it
+		time.Sleep(time.Millisecond)
+		if job.Status != jobs.StatusQueued {
+			t.Fatalf("submitted job status = %s, want %s", job.Status, jobs.StatusQueued)
+		}
+		submitted = append(submitted, job)
+	}
+
+	for _, job := range submitted {
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			got, err := service.GetJob(ctx, job.ID)
+			if err != nil {
+				t.Fatalf("GetJob() error = %v", err)
+			}
+			if got.Status == jobs.StatusSucceeded {
+				if got.DogID != "1" || got.ImageURL == "" || got.SubBreed != "afghan" {
+					t.Fatalf("GetJob() = %+v, want dog 1 of hound/afghan with its URL", got)
+				}
+				break
+			}
+			if got.Status == jobs.StatusFailed || time.Now().After(deadline) {
+				t.Fatalf("job %s is %s (%s), want %s", job.ID, got.Status, got.Error, jobs.StatusSucceeded)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+}
+
+func TestSubmitQueueFull(t *testing.T) {
+	repository := &memoryRepository{jobs: map[string]jobs.Job{}}
+	// no workers are started, the queue holds a single job
+	service := NewJobsService(stubDogs{}, repository, config.JobsConfig{Workers: 1, QueueSize: 1, Timeout: time.Second, Instance: "test"})
+	ctx := context.Background()
+
+	if _, err := service.SubmitDogImage(ctx, "hound", ""); err != nil {
+		t.Fatalf("SubmitDogImage() error = %v", err)
+	}
+	if _, err := service.SubmitDogImage(ctx, "hound", ""); !errors.Is(err, jobs.ErrQueueFull) {
+		t.Fatalf("SubmitDogImage() error = %v, want %v", err, jobs.ErrQueueFull)
+	}
+}
+
+func TestRunFailsUnfinishedJobs(t *testing.T) {
+	repository := &memoryRepository{jobs: map[string]jobs.Job{
+		"queued":         {ID: "queued", Status: jobs.StatusQueued, Instance: "test"},
+		"running":        {ID: "running", Status: jobs.StatusRunning, Instance: "test"},
+		"other instance": {ID: "other instance", Status: jobs.StatusRunning, Instance: "other"},
+	}}
+	newTestService(t, repository, config.JobsConfig{Workers: 1, QueueSize: 1, Timeout: time.Second, Instance: "test"})
+
+	tests := []struct {
+		id   string
+		want jobs.Status
+	}{
+		{id: "queued", want: jobs.StatusFailed},
+		{id: "running", want: jobs.StatusFailed},
+		{id: "other instance", want: jobs.StatusRunning},
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for _, tt := range tests {
+		for {
+			got, _ := repository.GetJob(context.Background(), tt.id)
+			if got.Status == tt.want {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("job %q is %s, want %s", tt.id, got.Status, tt.want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+}
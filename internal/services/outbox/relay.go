@@ -0,0 +1,148 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"go-platform/internal/models/outbox"
+	"go-platform/pkg/broker/nats"
+	"go-platform/pkg/config"
+)
+
+type Repository interface {
+	ClaimPendingOutbox(ctx context.Context, limit int, lease time.Duration) ([]outbox.Message, error)
+	MarkOutboxPublished(ctx context.Context, ids []string) error
+	MarkOutboxFailed(ctx context.Context, id string, cause error, retryAt time.Time) error
+	MarkOutboxDead(ctx context.Context, id string, cause error) error
+}
+
+type Publisher interface {
+	PublishMessage(ctx context.Context, msg *nats.Message) error
+}
+
+// Relay drains the outbox table to the broker with at-least-once delivery.
+//
+// A message is marked published only after the broker accepted it, so a crash in between
+// publishes it again once its claim expires, with the same ID sent as Nats-Msg-Id.
+// JetStream drops such duplicates within its duplicate window, core NATS ignores the header:
+// consumers of core NATS subjects must deduplicate by Nats-Msg-Id themselves.
+//
+// Claims let several relays share the outbox, so messages are published roughly oldest first
+// but not in strict order. A message failing to publish is retried with backoff without holding
+// back the others and parked as dead after MaxAttempts failures, see the outbox dead_at column.
+type Relay struct {
+	repository   Repository
+	publisher    Publisher
+	pollInterval time.Duration
+	batchSize    int
+	claimTimeout time.Duration
+	maxAttempts  int
+	backoffBase  time.Duration
+	backoffMax   time.Duration
+}
+
+func NewRelay(repository Repository, publisher Publisher, cfg config.OutboxConfig) *Relay {
+	return &Relay{
+		repository:   repository,
+		publisher:    publisher,
+		pollInterval: cfg.PollInterval,
+		batchSize:    cfg.BatchSize,
+		claimTimeout: cfg.ClaimTimeout,
+		maxAttempts:  cfg.MaxAttempts,
+		backoffBase:  cfg.RetryBackoffBase,
+		backoffMax:   cfg.RetryBackoffMax,
+	}
+}
+
+// Run polls the outbox until ctx is cancelled
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	slog.Info("Starting outbox relay", "interval", r.pollInterval, "batch_size", r.batchSize, "max_attempts", r.maxAttempts)
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Stopping outbox relay")
+			return
+		case <-ticker.C:
+			r.drain(ctx)
+		}
+	}
+}
+
+// drain relays full batches until no message is ready
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		messages, err := r.repository.ClaimPendingOutbox(ctx, r.batchSize, r.claimTimeout)
+		if err != nil {
+			slog.Error("Failed to claim outbox", "error", err)
+			return
+		}
+
+		published := r.relay(ctx, messages)
+		if len(published) > 0 {
+			if err := r.repository.MarkOutboxPublished(ctx, published); err != nil {
+				slog.Error("Failed to mark outbox published", "count", len(published), "error", err)
+				return
+			}
+			slog.Info("Outbox messages relayed", "count", len(published))
+		}
+
+		if len(messages) < r.batchSize {
+			return
+		}
+	}
+}
+
+// relay publishes messages in order, failed messages are rescheduled and do not stop the batch
+func (r *Relay) relay(ctx context.Context, messages []outbox.Message) []string {
+	published := make([]string, 0, len(messages))
+
+	for _, msg := range messages {
+		err := r.publisher.PublishMessage(ctx, &nats.Message{
+			ID:        msg.ID,
+			EventType: msg.EventType,
+			Data:      msg.Payload,
+			Headers:   msg.Headers,
+		})
+		if err != nil {
+			r.fail(ctx, msg, err)
+			continue
+		}
+
+		published = append(published, msg.ID)
+	}
+
+	return published
+}
+
+// fail schedules the next attempt of msg or parks it once it used all attempts
+func (r *Relay) fail(ctx context.Context, msg outbox.Message, cause error) {
+	attempts := msg.Attempts + 1
+
+	if r.maxAttempts > 0 && attempts >= r.maxAttempts {
+		slog.Error("Outbox message dead after max attempts", "id", msg.ID, "event", msg.EventType, "attempts", attempts, "error", cause)
+		if err := r.repository.MarkOutboxDead(ctx, msg.ID, cause); err != nil {
+			slog.Error("Failed to mark outbox dead", "id", msg.ID, "error", err)
+		}
+		return
+	}
+
+	delay := r.backoff(attempts)
+	slog.Error("Failed to relay outbox message", "id", msg.ID, "event", msg.EventType, "attempts", attempts, "retry_in", delay, "error", cause)
+	if err := r.repository.MarkOutboxFailed(ctx, msg.ID, cause, time.Now().Add(delay)); err != nil {
+		slog.Error("Failed to mark outbox failed", "id", msg.ID, "error", err)
+	}
+}
+
+// backoff returns the delay after the given number of failed attempts: base * 2^(n-1) capped at max
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.backoffBase
+	for i := 1; i < attempts && delay < r.backoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, r.backoffMax)
+}
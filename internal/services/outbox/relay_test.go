@@ -0,0 +1,183 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"go-platform/internal/models/outbox"
+	"go-platform/pkg/broker/nats"
+	"go-platform/pkg/config"
+)
+
+// memoryRepository is an in-memory outbox honouring claims, retries and dead messages
+type memoryRepository struct {
+	mu       sync.Mutex
+	now      time.Time
+	messages []*memoryMessage
+}
+
+type memoryMessage struct {
+	outbox.Message
+	nextAttemptAt time.Time
+	published     bool
+	dead          bool
+	lastError     string
+}
+
+func (m *memoryRepository) add(ids ...string) {
+	for i, id := range ids {
+		m.messages = append(m.messages, &memoryMessage{Message: outbox.Message{
+			ID:        id,
+			EventType: "test.happened",
+			CreatedAt: m.now.Add(time.Duration(i) * time.Millisecond),
+		}})
+	}
+}
+
+func (m *memoryRepository) get(id string) *memoryMessage {
+	for _, msg := range m.messages {
+		if msg.ID == id {
+			return msg
+		}
+	}
+	return nil
+}
+
+func (m *memoryRepository) ClaimPendingOutbox(_ context.Context, limit int, lease time.Duration) ([]outbox.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var claimed []outbox.Message
+	for _, msg := range m.messages {
+		if len(claimed) == limit {
+			break
+		}
+		if msg.published || msg.dead || msg.nextAttemptAt.After(m.now) {
+			continue
+		}
+		msg.nextAttemptAt = m.now.Add(lease)
+		claimed = append(claimed, msg.Message)
+	}
+	return claimed, nil
+}
+
+func (m *memoryRepository) MarkOutboxPublished(_ context.Context, ids []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, id := range ids {
+		m.get(id).published = true
+	}
+	return nil
+}
+
+func (m *memoryRepository) MarkOutboxFailed(_ context.Context, id string, cause error, retryAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	msg := m.get(id)
+	msg.Attempts++
+	msg.lastError = cause.Error()
+	msg.nextAttemptAt = retryAt
+	return nil
+}
+
+func (m *memoryRepository) MarkOutboxDead(_ context.Context, id string, cause error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	msg := m.get(id)
+	msg.Attempts++
+	msg.lastError = cause.Error()
+	msg.dead = true
+	return nil
+}
+
+// failingPublisher fails every publish of the poisoned message IDs
+type failingPublisher struct {
+	poisoned  map[string]bool
+	published []string
+}
+
+func (p *failingPublisher) PublishMessage(_ context.Context, msg *nats.Message) error {
+	if p.poisoned[msg.ID] {
+		return errors.New("message too large")
+	}
+	p.published = append(p.published, msg.ID)
+	return nil
+}
+
+func testOutboxConfig() config.OutboxConfig {
+	return config.OutboxConfig{
+		PollInterval:     time.Second,
+		BatchSize:        2,
+		ClaimTimeout:     30 * time.Second,
+		MaxAttempts:      3,
+		RetryBackoffBase: time.Second,
+		RetryBackoffMax:  4 * time.Second,
+	}
+}
+
+func TestRelaySkipsFailedMessages(t *testing.T) {
+	repository := &memoryRepository{now: time.Now()}
+	repository.add("poison", "a", "b", "c")
+	publisher := &failingPublisher{poisoned: map[string]bool{"poison": true}}
+	relay := NewRelay(repository, publisher, testOutboxConfig())
+
+	relay.drain(context.Background())
+
+	if got, want := publisher.published, []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("published %v, want %v", got, want)
+	}
+	poison := repository.get("poison")
+	if poison.published || poison.dead {
+		t.Fatalf("poison message published %v dead %v, want pending", poison.published, poison.dead)
+	}
+	if poison.Attempts != 1 || poison.lastError == "" {
+		t.Fatalf("poison message has %d attempts and error %q, want 1 attempt with the error", poison.Attempts, poison.lastError)
+	}
+	if got, want := poison.nextAttemptAt.Sub(time.Now()), time.Second; got > want {
+		t.Fatalf("poison message retried in %v, want at most %v", got, want)
+	}
+}
+
+func TestRelayParksDeadMessages(t *testing.T) {
+	cfg := testOutboxConfig()
+	repository := &memoryRepository{now: time.Now()}
+	repository.add("poison")
+	relay := NewRelay(repository, &failingPublisher{poisoned: map[string]bool{"poison": true}}, cfg)
+
+	for attempt := 1; attempt <= cfg.MaxAttempts+2; attempt++ {
+		relay.drain(context.Background())
+		repository.now = repository.now.Add(cfg.RetryBackoffMax)
+	}
+
+	poison := repository.get("poison")
+	if !poison.dead {
+		t.Fatal("poison message is not dead")
+	}
+	if poison.Attempts != cfg.MaxAttempts {
+		t.Fatalf("poison message has %d attempts, want %d", poison.Attempts, cfg.MaxAttempts)
+	}
+}
+
+func TestRelayBackoff(t *testing.T) {
+	relay := NewRelay(nil, nil, testOutboxConfig())
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 3, want: 4 * time.Second},
+		{attempts: 10, want: 4 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := relay.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-platform/internal/models/breeds"
+)
+
+// ReplaceBreeds inserts the whole catalog as a new sync version, rows missing from it are no longer read
+func (r *ClickHouseRepository) ReplaceBreeds(ctx context.Context, catalog []breeds.Breed) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("replace", "breeds", time.Since(start))
+	}()
+
+	insert, err := r.clickhouse.Conn().PrepareBatch(ctx, "INSERT INTO breeds (name, sub_breed, synced_at)")
+	if err != nil {
+		r.dbMetrics.RecordError("replace", "breeds", "prepare")
+		return fmt.Errorf("failed to prepare ClickHouse batch: %w", err)
+	}
+	defer insert.Abort()
+
+	for _, row := range breeds.ToRows(catalog, start) {
+		if err := insert.Append(row.Name, row.SubBreed, row.SyncedAt); err != nil {
+			r.dbMetrics.RecordError("replace", "breeds", "append")
+			return fmt.Errorf("failed to append breed to ClickHouse batch: %w", err)
+		}
+	}
+
+	if err := insert.Send(); err != nil {
+		r.dbMetrics.RecordError("replace", "breeds", "query")
+		return fmt.Errorf("failed to insert breeds into ClickHouse: %w", err)
+	}
+
+	return nil
+}
+
+// ListBreeds returns the breed catalog of the latest sync ordered by name
+func (r *ClickHouseRepository) ListBreeds(ctx context.Context) ([]breeds.Breed, error) {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("select", "breeds", time.Since(start))
+	}()
+
+	query := `
+		SELECT name, sub_breed, synced_at
+		FROM breeds FINAL
+		WHERE synced_at = (SELECT max(synced_at) FROM breeds)
+		ORDER BY name, sub_breed`
+
+	rows, err := r.clickhouse.Conn().Query(ctx, query)
+	if err != nil {
+		r.dbMetrics.RecordError("select", "breeds", "query")
+		return nil, fmt.Errorf("failed to list breeds from ClickHouse: %w", err)
+	}
+	defer rows.Close()
+
+	var stored []breeds.Row
+	for rows.Next() {
+		var row breeds.Row
+		if err := rows.Scan(&row.Name, &row.SubBreed, &row.SyncedAt); err != nil {
+			r.dbMetrics.RecordError("select", "breeds", "scan")
+			return nil, fmt.Errorf("failed to scan breed from ClickHouse: %w", err)
+		}
+		stored = append(stored, row)
+	}
+	if err := rows.Err(); err != nil {
+		r.dbMetrics.RecordError("select", "breeds", "rows")
+		return nil, fmt.Errorf("failed to iterate breeds from ClickHouse: %w", err)
+	}
+
+	return breeds.FromRows(stored), nil
+}
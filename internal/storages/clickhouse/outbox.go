@@ -0,0 +1,171 @@
+package clickhouse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-platform/internal/models/outbox"
+)
+
+// Outbox rows are never updated in place: every state change inserts a new version
+// of the row and ReplacingMergeTree keeps the latest one, reads go through FINAL.
+
+func (r *ClickHouseRepository) insertOutbox(ctx context.Context, msg *outbox.Message) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("insert", "outbox", time.Since(start))
+	}()
+
+	headers, err := json.Marshal(msg.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox headers: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox (id, event_type, payload, headers, created_at)
+		VALUES (?, ?, ?, ?, ?)`
+
+	err = r.clickhouse.Conn().Exec(ctx, query, msg.ID, msg.EventType, string(msg.Payload), string(headers), time.Now())
+	if err != nil {
+		r.dbMetrics.RecordError("insert", "outbox", "query")
+		return fmt.Errorf("failed to insert outbox message into ClickHouse: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimPendingOutbox returns up to limit unpublished messages, oldest first, and hides them
+// from later claims for lease. ClickHouse has no row locks so the claim is not atomic:
+// relays running concurrently against ClickHouse may publish a message twice.
+func (r *ClickHouseRepository) ClaimPendingOutbox(ctx context.Context, limit int, lease time.Duration) ([]outbox.Message, error) {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("select", "outbox", time.Since(start))
+	}()
+
+	query := `
+		SELECT id, event_type, payload, headers, attempts, created_at
+		FROM outbox FINAL
+		WHERE published_at IS NULL
+			AND dead_at IS NULL
+			AND (next_attempt_at IS NULL OR next_attempt_at <= ?)
+		ORDER BY created_at
+		LIMIT ?`
+
+	now := time.Now()
+	rows, err := r.clickhouse.Conn().Query(ctx, query, now, limit)
+	if err != nil {
+		r.dbMetrics.RecordError("select", "outbox", "query")
+		return nil, fmt.Errorf("failed to fetch outbox from ClickHouse: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		messages []outbox.Message
+		ids      []string
+	)
+	for rows.Next() {
+		var (
+			msg      outbox.Message
+			payload  string
+			headers  string
+			attempts uint32
+		)
+		if err := rows.Scan(&msg.ID, &msg.EventType, &payload, &headers, &attempts, &msg.CreatedAt); err != nil {
+			r.dbMetrics.RecordError("select", "outbox", "scan")
+			return nil, fmt.Errorf("failed to scan outbox message from ClickHouse: %w", err)
+		}
+		if err := json.Unmarshal([]byte(headers), &msg.Headers); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal outbox headers: %w", err)
+		}
+		msg.Payload = []byte(payload)
+		msg.Attempts = int(attempts)
+		messages = append(messages, msg)
+		ids = append(ids, msg.ID)
+	}
+	if err := rows.Err(); err != nil {
+		r.dbMetrics.RecordError("select", "outbox", "rows")
+		return nil, fmt.Errorf("failed to iterate outbox from ClickHouse: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	claim := `
+		INSERT INTO outbox (id, event_type, payload, headers, attempts, last_error, next_attempt_at, created_at, published_at, dead_at, version)
+		SELECT id, event_type, payload, headers, attempts, last_error, ?, created_at, published_at, dead_at, version + 1
+		FROM outbox FINAL
+		WHERE id IN (?)`
+
+	if err := r.clickhouse.Conn().Exec(ctx, claim, now.Add(lease), ids); err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "query")
+		return nil, fmt.Errorf("failed to claim outbox in ClickHouse: %w", err)
+	}
+
+	return messages, nil
+}
+
+// MarkOutboxPublished marks the messages as relayed to the broker
+func (r *ClickHouseRepository) MarkOutboxPublished(ctx context.Context, ids []string) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("update", "outbox", time.Since(start))
+	}()
+
+	query := `
+		INSERT INTO outbox (id, event_type, payload, headers, attempts, last_error, next_attempt_at, created_at, published_at, dead_at, version)
+		SELECT id, event_type, payload, headers, attempts, last_error, next_attempt_at, created_at, now64(3), dead_at, version + 1
+		FROM outbox FINAL
+		WHERE id IN (?)`
+
+	if err := r.clickhouse.Conn().Exec(ctx, query, ids); err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "query")
+		return fmt.Errorf("failed to mark outbox published in ClickHouse: %w", err)
+	}
+
+	return nil
+}
+
+// MarkOutboxFailed records a failed relay attempt, the message is retried from retryAt
+func (r *ClickHouseRepository) MarkOutboxFailed(ctx context.Context, id string, cause error, retryAt time.Time) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("update", "outbox", time.Since(start))
+	}()
+
+	query := `
+		INSERT INTO outbox (id, event_type, payload, headers, attempts, last_error, next_attempt_at, created_at, published_at, dead_at, version)
+		SELECT id, event_type, payload, headers, attempts + 1, ?, ?, created_at, published_at, dead_at, version + 1
+		FROM outbox FINAL
+		WHERE id = ?`
+
+	if err := r.clickhouse.Conn().Exec(ctx, query, cause.Error(), retryAt, id); err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "query")
+		return fmt.Errorf("failed to mark outbox failed in ClickHouse: %w", err)
+	}
+
+	return nil
+}
+
+// MarkOutboxDead records the last failed attempt and parks the message, it is not relayed again
+func (r *ClickHouseRepository) MarkOutboxDead(ctx context.Context, id string, cause error) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("update", "outbox", time.Since(start))
+	}()
+
+	query := `
+		INSERT INTO outbox (id, event_type, payload, headers, attempts, last_error, next_attempt_at, created_at, published_at, dead_at, version)
+		SELECT id, event_type, payload, headers, attempts + 1, ?, next_attempt_at, created_at, published_at, now64(3), version + 1
+		FROM outbox FINAL
+		WHERE id = ?`
+
+	if err := r.clickhouse.Conn().Exec(ctx, query, cause.Error(), id); err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "query")
+		return fmt.Errorf("failed to mark outbox dead in ClickHouse: %w", err)
+	}
+
+	return nil
+}
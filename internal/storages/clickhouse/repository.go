@@ -2,11 +2,15 @@ package clickhouse
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"go-platform/internal/models/dogs"
+	"go-platform/internal/models/outbox"
 	"go-platform/pkg/db/clickhouse"
 	"go-platform/pkg/metrics"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,6 +21,27 @@ type ClickHouseRepositoryMetricsInterface interface {
 	RecordError(operation, table, errorType string)
 }
 
+// dogColumns are selected for every dog read, in the order scanned by scanDog
+const dogColumns = `id, breed, sub_breed, image_key, image_url, content_hash, width, height, mime_type, thumbnail_key, medium_key, created_at`
+
+func scanDog(row interface{ Scan(dest ...any) error }, dog *dogs.Dog) error {
+	// the driver only scans UInt32 into uint32
+	var width, height uint32
+	err := row.Scan(&dog.ID, &dog.Breed, &dog.SubBreed, &dog.ImageKey, &dog.ImageURL, &dog.ContentHash,
+		&width, &height, &dog.MIMEType, &dog.ThumbnailKey, &dog.MediumKey, &dog.CreatedAt)
+	if err != nil {
+		return err
+	}
+	dog.Width, dog.Height = int(width), int(height)
+	return nil
+}
+
+// dogValues are inserted for every dog, in the order of the insert column lists
+func dogValues(id string, dog *dogs.Dog) []any {
+	return []any{id, dog.Breed, dog.SubBreed, dog.ImageKey, dog.ContentHash, uint32(dog.Width), uint32(dog.Height),
+		dog.MIMEType, dog.ThumbnailKey, dog.MediumKey, dog.CreatedAt}
+}
+
 type ClickHouseRepository struct {
 	clickhouse *clickhouse.ClickHouseClient
 	dbMetrics  ClickHouseRepositoryMetricsInterface
@@ -26,20 +51,251 @@ func NewClickHouseRepository(clickhouse *clickhouse.ClickHouseClient, dbMetrics
 	return &ClickHouseRepository{clickhouse: clickhouse, dbMetrics: dbMetrics}
 }
 
-// InsertDog inserts a dog into ClickHouse
-func (r *ClickHouseRepository) InsertDog(ctx context.Context, dog *dogs.Dog) (string, error) {
+// InsertDog inserts a dog into ClickHouse followed by its outbox message.
+// ClickHouse has no transactions, so a crash in between loses the event but never the dog.
+// If a dog with the same content hash is already stored, dog is replaced with the stored row,
+// its ID is returned and no outbox message is written. ClickHouse has no unique constraints,
+// so concurrent inserts of the same image may still both be stored.
+func (r *ClickHouseRepository) InsertDog(ctx context.Context, dog *dogs.Dog, outboxFn outbox.MessageFunc) (string, error) {
+	existing, err := r.dogsByContentHash(ctx, dogs.ContentHashes([]*dogs.Dog{dog}))
+	if err != nil {
+		return "", err
+	}
+	if stored, ok := existing[dog.ContentHash]; ok {
+		*dog = stored
+		slog.InfoContext(ctx, "Dog already stored in ClickHouse", "id", dog.ID, "content_hash", dog.ContentHash)
+		return dog.ID, nil
+	}
+
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("insert", "dogs", time.Since(start))
+	}()
+
 	query := `
-		INSERT INTO dogs (id, breed, image_url, created_at)
-		VALUES (?, ?, ?, ?)`
+		INSERT INTO dogs (id, breed, sub_breed, image_key, content_hash, width, height, mime_type, thumbnail_key, medium_key, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	id := uuid.New().String()
+	dog.CreatedAt = time.Now()
 
-	err := r.clickhouse.Conn().Exec(ctx, query, id, dog.Breed, dog.ImageURL, dog.CreatedAt)
+	err = r.clickhouse.Conn().Exec(ctx, query, dogValues(id, dog)...)
 	if err != nil {
-		slog.Error("Failed to insert dog into ClickHouse", "error", err)
+		r.dbMetrics.RecordError("insert", "dogs", "query")
+		slog.ErrorContext(ctx, "Failed to insert dog into ClickHouse", "error", err)
 		return "", fmt.Errorf("failed to insert dog into ClickHouse: %w", err)
 	}
 
-	slog.Info("Dog inserted into ClickHouse", "id", id)
+	slog.InfoContext(ctx, "Dog inserted into ClickHouse", "id", id)
+
+	if outboxFn != nil {
+		msg, err := outboxFn(id)
+		if err != nil {
+			return "", fmt.Errorf("failed to build outbox message: %w", err)
+		}
+		if err := r.insertOutbox(ctx, msg); err != nil {
+			return "", err
+		}
+	}
+
 	return id, nil
 }
+
+// InsertDogs inserts dogs with a single batch insert followed by their outbox messages.
+// Dogs already stored under the same content hash are replaced with the stored rows and get no outbox message.
+// Returned IDs follow the order of dogs.
+func (r *ClickHouseRepository) InsertDogs(ctx context.Context, batch []*dogs.Dog, outboxFn outbox.BatchMessageFunc) ([]string, error) {
+	if len(batch) == 0 {
+		return nil, nil
+	}
+
+	existing, err := r.dogsByContentHash(ctx, dogs.ContentHashes(batch))
+	if err != nil {
+		return nil, err
+	}
+	ids, fresh := dogs.SplitByContentHash(batch, existing)
+	if len(fresh) == 0 {
+		slog.InfoContext(ctx, "Dogs already stored in ClickHouse", "count", len(ids))
+		return ids, nil
+	}
+
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("insert_batch", "dogs", time.Since(start))
+	}()
+
+	insert, err := r.clickhouse.Conn().PrepareBatch(ctx, "INSERT INTO dogs (id, breed, sub_breed, image_key, content_hash, width, height, mime_type, thumbnail_key, medium_key, created_at)")
+	if err != nil {
+		r.dbMetrics.RecordError("insert_batch", "dogs", "prepare")
+		return nil, fmt.Errorf("failed to prepare ClickHouse batch: %w", err)
+	}
+	defer insert.Abort()
+
+	now := time.Now()
+	for _, i := range fresh {
+		dog := batch[i]
+		ids[i] = uuid.New().String()
+		dog.CreatedAt = now
+		if err := insert.Append(dogValues(ids[i], dog)...); err != nil {
+			return nil, fmt.Errorf("failed to append dog to ClickHouse batch: %w", err)
+		}
+	}
+
+	if err := insert.Send(); err != nil {
+		r.dbMetrics.RecordError("insert_batch", "dogs", "query")
+		slog.ErrorContext(ctx, "Failed to insert dogs into ClickHouse", "count", len(fresh), "error", err)
+		return nil, fmt.Errorf("failed to insert dogs into ClickHouse: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Dogs inserted into ClickHouse", "inserted", len(fresh), "existing", len(ids)-len(fresh))
+
+	if outboxFn != nil {
+		for _, i := range fresh {
+			msg, err := outboxFn(i, ids[i])
+			if err != nil {
+				return nil, fmt.Errorf("failed to build outbox message: %w", err)
+			}
+			if err := r.insertOutbox(ctx, msg); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// dogsByContentHash returns stored dogs keyed by content hash
+func (r *ClickHouseRepository) dogsByContentHash(ctx context.Context, hashes []string) (map[string]dogs.Dog, error) {
+	existing := make(map[string]dogs.Dog, len(hashes))
+	if len(hashes) == 0 {
+		return existing, nil
+	}
+
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("select", "dogs", time.Since(start))
+	}()
+
+	query := `
+		SELECT ` + dogColumns + `
+		FROM dogs
+		WHERE content_hash IN (?)
+		ORDER BY created_at
+		LIMIT 1 BY content_hash`
+
+	rows, err := r.clickhouse.Conn().Query(ctx, query, hashes)
+	if err != nil {
+		r.dbMetrics.RecordError("select", "dogs", "query")
+		return nil, fmt.Errorf("failed to get dogs by content hash from ClickHouse: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dog dogs.Dog
+		if err := scanDog(rows, &dog); err != nil {
+			r.dbMetrics.RecordError("select", "dogs", "scan")
+			return nil, fmt.Errorf("failed to scan dog from ClickHouse: %w", err)
+		}
+		existing[dog.ContentHash] = dog
+	}
+	if err := rows.Err(); err != nil {
+		r.dbMetrics.RecordError("select", "dogs", "rows")
+		return nil, fmt.Errorf("failed to iterate dogs from ClickHouse: %w", err)
+	}
+
+	return existing, nil
+}
+
+// GetDogByID returns a single dog by its ID
+func (r *ClickHouseRepository) GetDogByID(ctx context.Context, id string) (*dogs.Dog, error) {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("select", "dogs", time.Since(start))
+	}()
+
+	query := `
+		SELECT ` + dogColumns + `
+		FROM dogs
+		WHERE id = ?
+		LIMIT 1`
+
+	var dog dogs.Dog
+	err := scanDog(r.clickhouse.Conn().QueryRow(ctx, query, id), &dog)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, dogs.ErrDogNotFound
+		}
+		r.dbMetrics.RecordError("select", "dogs", "query")
+		slog.ErrorContext(ctx, "Failed to get dog from ClickHouse", "id", id, "error", err)
+		return nil, fmt.Errorf("failed to get dog from ClickHouse: %w", err)
+	}
+
+	return &dog, nil
+}
+
+// ListDogs returns a page of dogs ordered from newest to oldest
+func (r *ClickHouseRepository) ListDogs(ctx context.Context, filter dogs.ListDogsFilter) (*dogs.DogsPage, error) {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("select", "dogs", time.Since(start))
+	}()
+
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if filter.Breed != "" {
+		conditions = append(conditions, "breed = ?")
+		args = append(args, filter.Breed)
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.To)
+	}
+	if filter.Cursor != "" {
+		cursor, err := dogs.DecodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, "(created_at < ? OR (created_at = ? AND id < ?))")
+		args = append(args, cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	}
+
+	query := `
+		SELECT ` + dogColumns + `
+		FROM dogs`
+	if len(conditions) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(conditions, " AND ")
+	}
+	query += "\n\t\tORDER BY created_at DESC, id DESC\n\t\tLIMIT ?"
+	args = append(args, filter.Limit+1)
+
+	rows, err := r.clickhouse.Conn().Query(ctx, query, args...)
+	if err != nil {
+		r.dbMetrics.RecordError("select", "dogs", "query")
+		slog.ErrorContext(ctx, "Failed to list dogs from ClickHouse", "error", err)
+		return nil, fmt.Errorf("failed to list dogs from ClickHouse: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]dogs.Dog, 0, filter.Limit+1)
+	for rows.Next() {
+		var dog dogs.Dog
+		if err := scanDog(rows, &dog); err != nil {
+			r.dbMetrics.RecordError("select", "dogs", "scan")
+			return nil, fmt.Errorf("failed to scan dog from ClickHouse: %w", err)
+		}
+		result = append(result, dog)
+	}
+	if err := rows.Err(); err != nil {
+		r.dbMetrics.RecordError("select", "dogs", "rows")
+		return nil, fmt.Errorf("failed to iterate dogs from ClickHouse: %w", err)
+	}
+
+	return dogs.NewDogsPage(result, filter.Limit), nil
+}
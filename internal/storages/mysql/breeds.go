@@ -0,0 +1,78 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-platform/internal/models/breeds"
+)
+
+// ReplaceBreeds replaces the stored breed catalog in one transaction
+func (r *MySQLRepository) ReplaceBreeds(ctx context.Context, catalog []breeds.Breed) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("replace", "breeds", time.Since(start))
+	}()
+
+	rows := breeds.ToRows(catalog, start)
+
+	tx, err := r.mysql.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		r.dbMetrics.RecordError("replace", "breeds", "begin")
+		return fmt.Errorf("failed to begin MySQL transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM breeds`); err != nil {
+		r.dbMetrics.RecordError("replace", "breeds", "delete")
+		return fmt.Errorf("failed to delete breeds from MySQL: %w", err)
+	}
+
+	if len(rows) > 0 {
+		values := make([]string, 0, len(rows))
+		args := make([]any, 0, len(rows)*3)
+		for _, row := range rows {
+			values = append(values, "(?, ?, ?)")
+			args = append(args, row.Name, row.SubBreed, row.SyncedAt)
+		}
+
+		query := `
+		INSERT INTO breeds (name, sub_breed, synced_at)
+		VALUES ` + strings.Join(values, ", ")
+
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			r.dbMetrics.RecordError("replace", "breeds", "query")
+			return fmt.Errorf("failed to insert breeds into MySQL: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.dbMetrics.RecordError("replace", "breeds", "commit")
+		return fmt.Errorf("failed to commit MySQL transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListBreeds returns the stored breed catalog ordered by name
+func (r *MySQLRepository) ListBreeds(ctx context.Context) ([]breeds.Breed, error) {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("select", "breeds", time.Since(start))
+	}()
+
+	query := `
+		SELECT name, sub_breed, synced_at
+		FROM breeds
+		ORDER BY name, sub_breed`
+
+	var rows []breeds.Row
+	if err := r.mysql.DB().SelectContext(ctx, &rows, query); err != nil {
+		r.dbMetrics.RecordError("select", "breeds", "query")
+		return nil, fmt.Errorf("failed to list breeds from MySQL: %w", err)
+	}
+
+	return breeds.FromRows(rows), nil
+}
@@ -0,0 +1,180 @@
+package mysql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-platform/internal/models/outbox"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type outboxRow struct {
+	ID        string    `db:"id"`
+	EventType string    `db:"event_type"`
+	Payload   []byte    `db:"payload"`
+	Headers   []byte    `db:"headers"`
+	Attempts  int       `db:"attempts"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (r *MySQLRepository) insertOutbox(ctx context.Context, tx *sqlx.Tx, msg *outbox.Message) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("insert", "outbox", time.Since(start))
+	}()
+
+	headers, err := json.Marshal(msg.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox headers: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox (id, event_type, payload, headers, created_at)
+		VALUES (?, ?, ?, ?, ?)`
+
+	_, err = tx.ExecContext(ctx, query, msg.ID, msg.EventType, msg.Payload, headers, time.Now())
+	if err != nil {
+		r.dbMetrics.RecordError("insert", "outbox", "query")
+		return fmt.Errorf("failed to insert outbox message into MySQL: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimPendingOutbox claims up to limit unpublished messages, oldest first, and hides them
+// from other relays for lease. Rows locked by a concurrent claim are skipped.
+func (r *MySQLRepository) ClaimPendingOutbox(ctx context.Context, limit int, lease time.Duration) ([]outbox.Message, error) {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("update", "outbox", time.Since(start))
+	}()
+
+	tx, err := r.mysql.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "begin")
+		return nil, fmt.Errorf("failed to begin MySQL transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT id, event_type, payload, headers, attempts, created_at
+		FROM outbox
+		WHERE published_at IS NULL
+			AND dead_at IS NULL
+			AND (next_attempt_at IS NULL OR next_attempt_at <= ?)
+		ORDER BY created_at
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED`
+
+	now := time.Now()
+	var rows []outboxRow
+	if err := tx.SelectContext(ctx, &rows, query, now, limit); err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "query")
+		return nil, fmt.Errorf("failed to claim outbox from MySQL: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	messages := make([]outbox.Message, 0, len(rows))
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		msg := outbox.Message{
+			ID:        row.ID,
+			EventType: row.EventType,
+			Payload:   row.Payload,
+			Attempts:  row.Attempts,
+			CreatedAt: row.CreatedAt,
+		}
+		if err := json.Unmarshal(row.Headers, &msg.Headers); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal outbox headers: %w", err)
+		}
+		messages = append(messages, msg)
+		ids = append(ids, row.ID)
+	}
+
+	claim, args, err := sqlx.In(`
+		UPDATE outbox
+		SET next_attempt_at = ?
+		WHERE id IN (?)`, now.Add(lease), ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build outbox claim: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, claim, args...); err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "query")
+		return nil, fmt.Errorf("failed to claim outbox in MySQL: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "commit")
+		return nil, fmt.Errorf("failed to commit MySQL transaction: %w", err)
+	}
+
+	return messages, nil
+}
+
+// MarkOutboxPublished marks the messages as relayed to the broker
+func (r *MySQLRepository) MarkOutboxPublished(ctx context.Context, ids []string) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("update", "outbox", time.Since(start))
+	}()
+
+	query, args, err := sqlx.In(`
+		UPDATE outbox
+		SET published_at = ?
+		WHERE id IN (?)`, time.Now(), ids)
+	if err != nil {
+		return fmt.Errorf("failed to build outbox update: %w", err)
+	}
+
+	if _, err := r.mysql.DB().ExecContext(ctx, query, args...); err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "query")
+		return fmt.Errorf("failed to mark outbox published in MySQL: %w", err)
+	}
+
+	return nil
+}
+
+// MarkOutboxFailed records a failed relay attempt, the message is retried from retryAt
+func (r *MySQLRepository) MarkOutboxFailed(ctx context.Context, id string, cause error, retryAt time.Time) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("update", "outbox", time.Since(start))
+	}()
+
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1, last_error = ?, next_attempt_at = ?
+		WHERE id = ?`
+
+	if _, err := r.mysql.DB().ExecContext(ctx, query, cause.Error(), retryAt, id); err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "query")
+		return fmt.Errorf("failed to mark outbox failed in MySQL: %w", err)
+	}
+
+	return nil
+}
+
+// MarkOutboxDead records the last failed attempt and parks the message, it is not relayed again
+func (r *MySQLRepository) MarkOutboxDead(ctx context.Context, id string, cause error) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("update", "outbox", time.Since(start))
+	}()
+
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1, last_error = ?, dead_at = ?
+		WHERE id = ?`
+
+	if _, err := r.mysql.DB().ExecContext(ctx, query, cause.Error(), time.Now(), id); err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "query")
+		return fmt.Errorf("failed to mark outbox dead in MySQL: %w", err)
+	}
+
+	return nil
+}
@@ -2,13 +2,22 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 
 	models "go-platform/internal/models/dogs"
+	"go-platform/internal/models/outbox"
 	"go-platform/pkg/db/mysql"
 	"go-platform/pkg/metrics"
+	"go-platform/pkg/utils/errs"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
 )
 
 type MySQLRepositoryMetricsInterface interface {
@@ -16,6 +25,17 @@ type MySQLRepositoryMetricsInterface interface {
 	RecordError(operation, table, errorType string)
 }
 
+// dogColumns are selected for every dog read and named after the models.Dog db tags
+const dogColumns = `id, breed, sub_breed, COALESCE(image_key, '') AS image_key, COALESCE(image_url, '') AS image_url,
+		COALESCE(content_hash, '') AS content_hash, COALESCE(width, 0) AS width, COALESCE(height, 0) AS height,
+		COALESCE(mime_type, '') AS mime_type, COALESCE(thumbnail_key, '') AS thumbnail_key,
+		COALESCE(medium_key, '') AS medium_key, created_at`
+
+// dogValues are inserted for every dog, in the order of the insert column lists
+func dogValues(dog *models.Dog) []any {
+	return []any{dog.Breed, dog.SubBreed, dog.ImageKey, dog.ContentHash, dog.Width, dog.Height, dog.MIMEType, dog.ThumbnailKey, dog.MediumKey, dog.CreatedAt}
+}
+
 type MySQLRepository struct {
 	mysql     *mysql.MySQLClient
 	dbMetrics MySQLRepositoryMetricsInterface
@@ -25,32 +45,288 @@ func NewMySQLRepository(mysql *mysql.MySQLClient, dbMetrics *metrics.DatabaseMet
 	return &MySQLRepository{mysql: mysql, dbMetrics: dbMetrics}
 }
 
-// InsertDog inserts a dog into MySQL
-func (r *MySQLRepository) InsertDog(ctx context.Context, dog *models.Dog) (string, error) {
+// InsertDog inserts a dog into MySQL together with its outbox message in one transaction.
+// If a dog with the same content hash is already stored, dog is replaced with the stored row,
+// its ID is returned and no outbox message is written.
+func (r *MySQLRepository) InsertDog(ctx context.Context, dog *models.Dog, outboxFn outbox.MessageFunc) (string, error) {
 	start := time.Now()
 	defer func() {
 		r.dbMetrics.RecordQuery("insert", "dogs", time.Since(start))
 	}()
 
+	// LAST_INSERT_ID(id) makes LastInsertId return the stored row ID on a duplicate,
+	// and the row is left untouched so no rows are affected
 	query := `
-		INSERT INTO dogs (breed, image_url, created_at)
-		VALUES (?, ?, ?)`
+		INSERT INTO dogs (breed, sub_breed, image_key, content_hash, width, height, mime_type, thumbnail_key, medium_key, created_at)
+		VALUES (?, ?, ?, NULLIF(?, ''), ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id)`
 
 	dog.CreatedAt = time.Now()
 
-	result, err := r.mysql.DB().ExecContext(ctx, query, dog.Breed, dog.ImageURL, dog.CreatedAt)
+	tx, err := r.mysql.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		r.dbMetrics.RecordError("insert", "dogs", "begin")
+		return "", fmt.Errorf("failed to begin MySQL transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, dogValues(dog)...)
 	if err != nil {
-		slog.Error("Failed to insert dog into MySQL", "error", err)
-		return "", fmt.Errorf("failed to insert dog into MySQL: %w", err)
+		r.dbMetrics.RecordError("insert", "dogs", "query")
+		slog.ErrorContext(ctx, "Failed to insert dog into MySQL", "error", err)
+		return "", fmt.Errorf("failed to insert dog into MySQL: %w", wrapError(err))
 	}
 
 	// Get the inserted ID
 	lastID, err := result.LastInsertId()
 	if err != nil {
-		slog.Error("Failed to get last insert ID from MySQL", "error", err)
+		slog.ErrorContext(ctx, "Failed to get last insert ID from MySQL", "error", err)
 		return "", fmt.Errorf("failed to get last insert ID from MySQL: %w", err)
 	}
+	dogID := strconv.FormatInt(lastID, 10)
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return "", fmt.Errorf("failed to get affected rows from MySQL: %w", err)
+	}
+	if affected == 0 {
+		existing, err := r.dogsByContentHash(ctx, tx, []string{dog.ContentHash})
+		if err != nil {
+			return "", err
+		}
+		stored, ok := existing[dog.ContentHash]
+		if !ok {
+			return "", errs.Errorf(errs.Conflict, "failed to find dog with content hash %s in MySQL", dog.ContentHash)
+		}
+		*dog = stored
+
+		slog.InfoContext(ctx, "Dog already stored in MySQL", "id", dog.ID, "content_hash", dog.ContentHash)
+		return dog.ID, nil
+	}
+
+	if outboxFn != nil {
+		msg, err := outboxFn(dogID)
+		if err != nil {
+			return "", fmt.Errorf("failed to build outbox message: %w", err)
+		}
+		if err := r.insertOutbox(ctx, tx, msg); err != nil {
+			return "", err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.dbMetrics.RecordError("insert", "dogs", "commit")
+		return "", fmt.Errorf("failed to commit MySQL transaction: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Dog inserted into MySQL", "id", lastID)
+	return dogID, nil
+}
+
+// InsertDogs inserts dogs with a single multi-row insert together with their outbox messages in one transaction.
+// Dogs already stored under the same content hash are replaced with the stored rows and get no outbox message.
+// Returned IDs follow the order of dogs: InnoDB allocates consecutive auto-increment values to a multi-row insert.
+func (r *MySQLRepository) InsertDogs(ctx context.Context, batch []*models.Dog, outboxFn outbox.BatchMessageFunc) ([]string, error) {
+	if len(batch) == 0 {
+		return nil, nil
+	}
+
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("insert_batch", "dogs", time.Since(start))
+	}()
+
+	tx, err := r.mysql.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		r.dbMetrics.RecordError("insert_batch", "dogs", "begin")
+		return nil, fmt.Errorf("failed to begin MySQL transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	existing, err := r.dogsByContentHash(ctx, tx, models.ContentHashes(batch))
+	if err != nil {
+		return nil, err
+	}
+	ids, fresh := models.SplitByContentHash(batch, existing)
+	if len(fresh) == 0 {
+		slog.InfoContext(ctx, "Dogs already stored in MySQL", "count", len(ids))
+		return ids, nil
+	}
+
+	now := time.Now()
+	values := make([]string, 0, len(fresh))
+	args := make([]any, 0, len(fresh)*10)
+	for _, i := range fresh {
+		dog := batch[i]
+		dog.CreatedAt = now
+		values = append(values, "(?, ?, ?, NULLIF(?, ''), ?, ?, ?, ?, ?, ?)")
+		args = append(args, dogValues(dog)...)
+	}
+
+	query := `
+		INSERT INTO dogs (breed, sub_breed, image_key, content_hash, width, height, mime_type, thumbnail_key, medium_key, created_at)
+		VALUES ` + strings.Join(values, ", ")
+
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		r.dbMetrics.RecordError("insert_batch", "dogs", "query")
+		slog.ErrorContext(ctx, "Failed to insert dogs into MySQL", "count", len(fresh), "error", err)
+		return nil, fmt.Errorf("failed to insert dogs into MySQL: %w", wrapError(err))
+	}
+
+	// LastInsertId is the ID of the first inserted row
+	firstID, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert ID from MySQL: %w", err)
+	}
+
+	for j, i := range fresh {
+		ids[i] = strconv.FormatInt(firstID+int64(j), 10)
+		if outboxFn == nil {
+			continue
+		}
+		msg, err := outboxFn(i, ids[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to build outbox message: %w", err)
+		}
+		if err := r.insertOutbox(ctx, tx, msg); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.dbMetrics.RecordError("insert_batch", "dogs", "commit")
+		return nil, fmt.Errorf("failed to commit MySQL transaction: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Dogs inserted into MySQL", "inserted", len(fresh), "existing", len(ids)-len(fresh))
+	return ids, nil
+}
 
-	slog.Info("Dog inserted into MySQL", "id", lastID)
-	return fmt.Sprintf("%d", lastID), nil
+// dogsByContentHash returns stored dogs keyed by content hash
+func (r *MySQLRepository) dogsByContentHash(ctx context.Context, tx *sqlx.Tx, hashes []string) (map[string]models.Dog, error) {
+	existing := make(map[string]models.Dog, len(hashes))
+	if len(hashes) == 0 {
+		return existing, nil
+	}
+
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("select", "dogs", time.Since(start))
+	}()
+
+	query, args, err := sqlx.In(`
+		SELECT `+dogColumns+`
+		FROM dogs
+		WHERE content_hash IN (?)`, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build MySQL query: %w", err)
+	}
+
+	var stored []models.Dog
+	if err := tx.SelectContext(ctx, &stored, query, args...); err != nil {
+		r.dbMetrics.RecordError("select", "dogs", "query")
+		return nil, fmt.Errorf("failed to get dogs by content hash from MySQL: %w", err)
+	}
+
+	for _, dog := range stored {
+		existing[dog.ContentHash] = dog
+	}
+
+	return existing, nil
+}
+
+// GetDogByID returns a single dog by its ID
+func (r *MySQLRepository) GetDogByID(ctx context.Context, id string) (*models.Dog, error) {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("select", "dogs", time.Since(start))
+	}()
+
+	query := `
+		SELECT ` + dogColumns + `
+		FROM dogs
+		WHERE id = ?`
+
+	var dog models.Dog
+	err := r.mysql.DB().GetContext(ctx, &dog, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrDogNotFound
+		}
+		r.dbMetrics.RecordError("select", "dogs", "query")
+		slog.ErrorContext(ctx, "Failed to get dog from MySQL", "id", id, "error", err)
+		return nil, fmt.Errorf("failed to get dog from MySQL: %w", err)
+	}
+
+	return &dog, nil
+}
+
+// ListDogs returns a page of dogs ordered from newest to oldest
+func (r *MySQLRepository) ListDogs(ctx context.Context, filter models.ListDogsFilter) (*models.DogsPage, error) {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("select", "dogs", time.Since(start))
+	}()
+
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if filter.Breed != "" {
+		conditions = append(conditions, "breed = ?")
+		args = append(args, filter.Breed)
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.From)
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.To)
+	}
+	if filter.Cursor != "" {
+		cursor, err := models.DecodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cursorID, err := strconv.Atoi(cursor.ID)
+		if err != nil {
+			return nil, models.ErrInvalidCursor
+		}
+		conditions = append(conditions, "(created_at < ? OR (created_at = ? AND id < ?))")
+		args = append(args, cursor.CreatedAt, cursor.CreatedAt, cursorID)
+	}
+
+	query := `
+		SELECT ` + dogColumns + `
+		FROM dogs`
+	if len(conditions) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(conditions, " AND ")
+	}
+	query += "\n\t\tORDER BY created_at DESC, id DESC\n\t\tLIMIT ?"
+	args = append(args, filter.Limit+1)
+
+	result := make([]models.Dog, 0, filter.Limit+1)
+	err := r.mysql.DB().SelectContext(ctx, &result, query, args...)
+	if err != nil {
+		r.dbMetrics.RecordError("select", "dogs", "query")
+		slog.ErrorContext(ctx, "Failed to list dogs from MySQL", "error", err)
+		return nil, fmt.Errorf("failed to list dogs from MySQL: %w", err)
+	}
+
+	return models.NewDogsPage(result, filter.Limit), nil
+}
+
+// duplicateEntry is the MySQL error number of a duplicate key
+const duplicateEntry = 1062
+
+// wrapError marks a duplicate key as a conflict, a concurrent writer stored the same row first
+func wrapError(err error) error {
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == duplicateEntry {
+		return errs.Wrap(errs.Conflict, err)
+	}
+	return err
 }
@@ -0,0 +1,82 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-platform/internal/models/breeds"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ReplaceBreeds replaces the stored breed catalog in one transaction
+func (r *PostgresRepository) ReplaceBreeds(ctx context.Context, catalog []breeds.Breed) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("replace", "breeds", time.Since(start))
+	}()
+
+	rows := breeds.ToRows(catalog, start)
+	names := make([]string, 0, len(rows))
+	subBreeds := make([]string, 0, len(rows))
+	for _, row := range rows {
+		names = append(names, row.Name)
+		subBreeds = append(subBreeds, row.SubBreed)
+	}
+
+	tx, err := r.postgres.Pool().Begin(ctx)
+	if err != nil {
+		r.dbMetrics.RecordError("replace", "breeds", "begin")
+		return fmt.Errorf("failed to begin PostgreSQL transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM breeds`); err != nil {
+		r.dbMetrics.RecordError("replace", "breeds", "delete")
+		return fmt.Errorf("failed to delete breeds from PostgreSQL: %w", err)
+	}
+
+	query := `
+		INSERT INTO breeds (name, sub_breed, synced_at)
+		SELECT unnest($1::text[]), unnest($2::text[]), $3`
+
+	if _, err := tx.Exec(ctx, query, names, subBreeds, start); err != nil {
+		r.dbMetrics.RecordError("replace", "breeds", "query")
+		return fmt.Errorf("failed to insert breeds into PostgreSQL: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.dbMetrics.RecordError("replace", "breeds", "commit")
+		return fmt.Errorf("failed to commit PostgreSQL transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListBreeds returns the stored breed catalog ordered by name
+func (r *PostgresRepository) ListBreeds(ctx context.Context) ([]breeds.Breed, error) {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("select", "breeds", time.Since(start))
+	}()
+
+	query := `
+		SELECT name, sub_breed, synced_at
+		FROM breeds
+		ORDER BY name, sub_breed`
+
+	rows, err := r.postgres.Pool().Query(ctx, query)
+	if err != nil {
+		r.dbMetrics.RecordError("select", "breeds", "query")
+		return nil, fmt.Errorf("failed to list breeds from PostgreSQL: %w", err)
+	}
+
+	stored, err := pgx.CollectRows(rows, pgx.RowToStructByName[breeds.Row])
+	if err != nil {
+		r.dbMetrics.RecordError("select", "breeds", "scan")
+		return nil, fmt.Errorf("failed to scan breeds from PostgreSQL: %w", err)
+	}
+
+	return breeds.FromRows(stored), nil
+}
@@ -0,0 +1,155 @@
+package postgresql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go-platform/internal/models/outbox"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (r *PostgresRepository) insertOutbox(ctx context.Context, tx pgx.Tx, msg *outbox.Message) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("insert", "outbox", time.Since(start))
+	}()
+
+	headers, err := json.Marshal(msg.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox headers: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox (id, event_type, payload, headers, created_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err = tx.Exec(ctx, query, msg.ID, msg.EventType, msg.Payload, headers, time.Now())
+	if err != nil {
+		r.dbMetrics.RecordError("insert", "outbox", "query")
+		return fmt.Errorf("failed to insert outbox message into PostgreSQL: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimPendingOutbox claims up to limit unpublished messages, oldest first, and hides them
+// from other relays for lease. Rows locked by a concurrent claim are skipped.
+func (r *PostgresRepository) ClaimPendingOutbox(ctx context.Context, limit int, lease time.Duration) ([]outbox.Message, error) {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("update", "outbox", time.Since(start))
+	}()
+
+	query := `
+		WITH claimed AS (
+			SELECT id
+			FROM outbox
+			WHERE published_at IS NULL
+				AND dead_at IS NULL
+				AND (next_attempt_at IS NULL OR next_attempt_at <= $1)
+			ORDER BY created_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE outbox
+		SET next_attempt_at = $3
+		FROM claimed
+		WHERE outbox.id = claimed.id
+		RETURNING outbox.id, outbox.event_type, outbox.payload, outbox.headers, outbox.attempts, outbox.created_at`
+
+	now := time.Now()
+	rows, err := r.postgres.Pool().Query(ctx, query, now, limit, now.Add(lease))
+	if err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "query")
+		return nil, fmt.Errorf("failed to claim outbox from PostgreSQL: %w", err)
+	}
+	defer rows.Close()
+
+	var messages []outbox.Message
+	for rows.Next() {
+		var (
+			msg     outbox.Message
+			headers []byte
+		)
+		if err := rows.Scan(&msg.ID, &msg.EventType, &msg.Payload, &headers, &msg.Attempts, &msg.CreatedAt); err != nil {
+			r.dbMetrics.RecordError("update", "outbox", "scan")
+			return nil, fmt.Errorf("failed to scan outbox message from PostgreSQL: %w", err)
+		}
+		if err := json.Unmarshal(headers, &msg.Headers); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal outbox headers: %w", err)
+		}
+		messages = append(messages, msg)
+	}
+	if err := rows.Err(); err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "rows")
+		return nil, fmt.Errorf("failed to iterate outbox from PostgreSQL: %w", err)
+	}
+
+	// RETURNING does not keep the order of the claim
+	outbox.SortByCreatedAt(messages)
+
+	return messages, nil
+}
+
+// MarkOutboxPublished marks the messages as relayed to the broker
+func (r *PostgresRepository) MarkOutboxPublished(ctx context.Context, ids []string) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("update", "outbox", time.Since(start))
+	}()
+
+	query := `
+		UPDATE outbox
+		SET published_at = $1
+		WHERE id = ANY($2)`
+
+	if _, err := r.postgres.Pool().Exec(ctx, query, time.Now(), ids); err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "query")
+		return fmt.Errorf("failed to mark outbox published in PostgreSQL: %w", err)
+	}
+
+	return nil
+}
+
+// MarkOutboxFailed records a failed relay attempt, the message is retried from retryAt
+func (r *PostgresRepository) MarkOutboxFailed(ctx context.Context, id string, cause error, retryAt time.Time) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("update", "outbox", time.Since(start))
+	}()
+
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1, last_error = $1, next_attempt_at = $2
+		WHERE id = $3`
+
+	if _, err := r.postgres.Pool().Exec(ctx, query, cause.Error(), retryAt, id); err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "query")
+		return fmt.Errorf("failed to mark outbox failed in PostgreSQL: %w", err)
+	}
+
+	return nil
+}
+
+// MarkOutboxDead records the last failed attempt and parks the message, it is not relayed again
+func (r *PostgresRepository) MarkOutboxDead(ctx context.Context, id string, cause error) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("update", "outbox", time.Since(start))
+	}()
+
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1, last_error = $1, dead_at = $2
+		WHERE id = $3`
+
+	if _, err := r.postgres.Pool().Exec(ctx, query, cause.Error(), time.Now(), id); err != nil {
+		r.dbMetrics.RecordError("update", "outbox", "query")
+		return fmt.Errorf("failed to mark outbox dead in PostgreSQL: %w", err)
+	}
+
+	return nil
+}
@@ -2,13 +2,21 @@ package postgresql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 
 	"go-platform/internal/models/dogs"
+	"go-platform/internal/models/outbox"
 	"go-platform/pkg/db/postgre"
 	"go-platform/pkg/metrics"
+	"go-platform/pkg/utils/errs"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type PostgresRepositoryMetricsInterface interface {
@@ -16,6 +24,20 @@ type PostgresRepositoryMetricsInterface interface {
 	RecordError(operation, table, errorType string)
 }
 
+// dogColumns are selected for every dog read, in the order scanned by scanDog
+const dogColumns = `id::text, breed, sub_breed, COALESCE(image_key, ''), COALESCE(image_url, ''), COALESCE(content_hash, ''),
+		COALESCE(width, 0), COALESCE(height, 0), COALESCE(mime_type, ''), COALESCE(thumbnail_key, ''), COALESCE(medium_key, ''), created_at`
+
+func scanDog(row pgx.Row, dog *dogs.Dog) error {
+	return row.Scan(&dog.ID, &dog.Breed, &dog.SubBreed, &dog.ImageKey, &dog.ImageURL, &dog.ContentHash,
+		&dog.Width, &dog.Height, &dog.MIMEType, &dog.ThumbnailKey, &dog.MediumKey, &dog.CreatedAt)
+}
+
+// dogValues are inserted for every dog, in the order of the insert column lists
+func dogValues(dog *dogs.Dog) []any {
+	return []any{dog.Breed, dog.SubBreed, dog.ImageKey, dog.ContentHash, dog.Width, dog.Height, dog.MIMEType, dog.ThumbnailKey, dog.MediumKey, dog.CreatedAt}
+}
+
 type PostgresRepository struct {
 	postgres  *postgre.PostgresClient
 	dbMetrics PostgresRepositoryMetricsInterface
@@ -25,28 +47,358 @@ func NewPostgresRepository(postgres *postgre.PostgresClient, dbMetrics *metrics.
 	return &PostgresRepository{postgres: postgres, dbMetrics: dbMetrics}
 }
 
-// InsertDog inserts a dog into PostgreSQL
-func (r *PostgresRepository) InsertDog(ctx context.Context, dog *dogs.Dog) (string, error) {
+// InsertDog inserts a dog into PostgreSQL together with its outbox message in one transaction.
+// If a dog with the same content hash is already stored, dog is replaced with the stored row,
+// its ID is returned and no outbox message is written.
+func (r *PostgresRepository) InsertDog(ctx context.Context, dog *dogs.Dog, outboxFn outbox.MessageFunc) (string, error) {
 	start := time.Now()
 	defer func() {
 		r.dbMetrics.RecordQuery("insert", "dogs", time.Since(start))
 	}()
 
 	query := `
-		INSERT INTO dogs (breed, image_url, created_at)
-		VALUES ($1, $2, $3)
+		INSERT INTO dogs (breed, sub_breed, image_key, content_hash, width, height, mime_type, thumbnail_key, medium_key, created_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (content_hash) DO NOTHING
 		RETURNING id`
 
 	dog.CreatedAt = time.Now()
 
+	tx, err := r.postgres.Pool().Begin(ctx)
+	if err != nil {
+		r.dbMetrics.RecordError("insert", "dogs", "begin")
+		return "", fmt.Errorf("failed to begin PostgreSQL transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
 	var id int
-	err := r.postgres.Pool().QueryRow(ctx, query, dog.Breed, dog.ImageURL, dog.CreatedAt).Scan(&id)
+	err = tx.QueryRow(ctx, query, dogValues(dog)...).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		existing, err := r.dogsByContentHash(ctx, tx, []string{dog.ContentHash})
+		if err != nil {
+			return "", err
+		}
+		stored, ok := existing[dog.ContentHash]
+		if !ok {
+			return "", errs.Errorf(errs.Conflict, "failed to find dog with content hash %s in PostgreSQL", dog.ContentHash)
+		}
+		*dog = stored
+
+		slog.InfoContext(ctx, "Dog already stored in PostgreSQL", "id", dog.ID, "content_hash", dog.ContentHash)
+		return dog.ID, nil
+	}
+	if err != nil {
+		r.dbMetrics.RecordError("insert", "dogs", "query")
+		slog.ErrorContext(ctx, "Failed to insert dog into PostgreSQL", "error", err)
+		return "", fmt.Errorf("failed to insert dog into PostgreSQL: %w", wrapError(err))
+	}
+	dogID := strconv.Itoa(id)
+
+	if outboxFn != nil {
+		msg, err := outboxFn(dogID)
+		if err != nil {
+			return "", fmt.Errorf("failed to build outbox message: %w", err)
+		}
+		if err := r.insertOutbox(ctx, tx, msg); err != nil {
+			return "", err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.dbMetrics.RecordError("insert", "dogs", "commit")
+		return "", fmt.Errorf("failed to commit PostgreSQL transaction: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Dog inserted into PostgreSQL", "id", id)
+
+	return dogID, nil
+}
+
+// InsertDogs inserts dogs with a single multi-row insert together with their outbox messages in one transaction.
+// Dogs already stored under the same content hash, also by a concurrent insert, are replaced with
+// the stored rows and get no outbox message. Returned IDs follow the order of dogs.
+func (r *PostgresRepository) InsertDogs(ctx context.Context, batch []*dogs.Dog, outboxFn outbox.BatchMessageFunc) ([]string, error) {
+	if len(batch) == 0 {
+		return nil, nil
+	}
+
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("insert_batch", "dogs", time.Since(start))
+	}()
+
+	tx, err := r.postgres.Pool().Begin(ctx)
 	if err != nil {
-		slog.Error("Failed to insert dog into PostgreSQL", "error", err)
-		return "", fmt.Errorf("failed to insert dog into PostgreSQL: %w", err)
+		r.dbMetrics.RecordError("insert_batch", "dogs", "begin")
+		return nil, fmt.Errorf("failed to begin PostgreSQL transaction: %w", err)
 	}
+	defer tx.Rollback(ctx)
 
-	slog.Info("Dog inserted into PostgreSQL", "id", id)
+	existing, err := r.dogsByContentHash(ctx, tx, dogs.ContentHashes(batch))
+	if err != nil {
+		return nil, err
+	}
+	ids, fresh := dogs.SplitByContentHash(batch, existing)
+	if len(fresh) == 0 {
+		slog.InfoContext(ctx, "Dogs already stored in PostgreSQL", "count", len(ids))
+		return ids, nil
+	}
+
+	now := time.Now()
+	values := make([]string, 0, len(fresh))
+	args := make([]any, 0, len(fresh)*9)
+	for _, i := range fresh {
+		dog := batch[i]
+		dog.CreatedAt = now
+		n := len(args)
+		values = append(values, fmt.Sprintf("($%d, $%d, $%d, NULLIF($%d, ''), $%d, $%d, $%d, $%d, $%d, $%d)",
+			n+1, n+2, n+3, n+4, n+5, n+6, n+7, n+8, n+9, n+10))
+		args = append(args, dogValues(dog)...)
+	}
+
+	// rows a concurrent insert stored meanwhile are skipped instead of failing the batch,
+	// returned rows are matched to dogs by content hash or, without one, by image key
+	query := `
+		INSERT INTO dogs (breed, sub_breed, image_key, content_hash, width, height, mime_type, thumbnail_key, medium_key, created_at)
+		VALUES ` + strings.Join(values, ", ") + `
+		ON CONFLICT (content_hash) DO NOTHING
+		RETURNING id, COALESCE(content_hash, ''), COALESCE(image_key, '')`
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		r.dbMetrics.RecordError("insert_batch", "dogs", "query")
+		slog.ErrorContext(ctx, "Failed to insert dogs into PostgreSQL", "count", len(fresh), "error", err)
+		return nil, fmt.Errorf("failed to insert dogs into PostgreSQL: %w", wrapError(err))
+	}
+	inserted := make(map[string]string, len(fresh))
+	_, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (struct{}, error) {
+		var (
+			id             int
+			hash, imageKey string
+		)
+		if err := row.Scan(&id, &hash, &imageKey); err != nil {
+			return struct{}{}, err
+		}
+		inserted[insertedKey(hash, imageKey)] = strconv.Itoa(id)
+		return struct{}{}, nil
+	})
+	if err != nil {
+		r.dbMetrics.RecordError("insert_batch", "dogs", "scan")
+		return nil, fmt.Errorf("failed to insert dogs into PostgreSQL: %w", wrapError(err))
+	}
+
+	var created, conflicted []int
+	for _, i := range fresh {
+		key := insertedKey(batch[i].ContentHash, batch[i].ImageKey)
+		if id, ok := inserted[key]; ok {
+			ids[i] = id
+			created = append(created, i)
+			// a later dog of the batch with the same hash is a duplicate of this one
+			delete(inserted, key)
+			continue
+		}
+		conflicted = append(conflicted, i)
+	}
 
-	return fmt.Sprintf("%d", id), nil
+	if len(conflicted) > 0 {
+		pending := make([]*dogs.Dog, 0, len(conflicted))
+		for _, i := range conflicted {
+			pending = append(pending, batch[i])
+		}
+		existing, err := r.dogsByContentHash(ctx, tx, dogs.ContentHashes(pending))
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range conflicted {
+			stored, ok := existing[batch[i].ContentHash]
+			if !ok {
+				return nil, errs.Errorf(errs.Conflict, "failed to find dog with content hash %s in PostgreSQL", batch[i].ContentHash)
+			}
+			*batch[i] = stored
+			ids[i] = stored.ID
+		}
+	}
+
+	if outboxFn != nil {
+		for _, i := range created {
+			msg, err := outboxFn(i, ids[i])
+			if err != nil {
+				return nil, fmt.Errorf("failed to build outbox message: %w", err)
+			}
+			if err := r.insertOutbox(ctx, tx, msg); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		r.dbMetrics.RecordError("insert_batch", "dogs", "commit")
+		return nil, fmt.Errorf("failed to commit PostgreSQL transaction: %w", err)
+	}
+
+	slog.InfoContext(ctx, "Dogs inserted into PostgreSQL", "inserted", len(created), "existing", len(ids)-len(created))
+
+	return ids, nil
+}
+
+// insertedKey identifies an inserted row by its content hash or, without one, by its image key
+func insertedKey(hash, imageKey string) string {
+	if hash != "" {
+		return "hash:" + hash
+	}
+	return "key:" + imageKey
+}
+
+// dogsByContentHash returns stored dogs keyed by content hash
+func (r *PostgresRepository) dogsByContentHash(ctx context.Context, tx pgx.Tx, hashes []string) (map[string]dogs.Dog, error) {
+	existing := make(map[string]dogs.Dog, len(hashes))
+	if len(hashes) == 0 {
+		return existing, nil
+	}
+
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("select", "dogs", time.Since(start))
+	}()
+
+	query := `
+		SELECT ` + dogColumns + `
+		FROM dogs
+		WHERE content_hash = ANY($1)`
+
+	rows, err := tx.Query(ctx, query, hashes)
+	if err != nil {
+		r.dbMetrics.RecordError("select", "dogs", "query")
+		return nil, fmt.Errorf("failed to get dogs by content hash from PostgreSQL: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var dog dogs.Dog
+		if err := scanDog(rows, &dog); err != nil {
+			r.dbMetrics.RecordError("select", "dogs", "scan")
+			return nil, fmt.Errorf("failed to scan dog from PostgreSQL: %w", err)
+		}
+		existing[dog.ContentHash] = dog
+	}
+	if err := rows.Err(); err != nil {
+		r.dbMetrics.RecordError("select", "dogs", "rows")
+		return nil, fmt.Errorf("failed to iterate dogs from PostgreSQL: %w", err)
+	}
+
+	return existing, nil
+}
+
+// GetDogByID returns a single dog by its ID
+func (r *PostgresRepository) GetDogByID(ctx context.Context, id string) (*dogs.Dog, error) {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("select", "dogs", time.Since(start))
+	}()
+
+	dogID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, dogs.ErrDogNotFound
+	}
+
+	query := `
+		SELECT ` + dogColumns + `
+		FROM dogs
+		WHERE id = $1`
+
+	var dog dogs.Dog
+	err = scanDog(r.postgres.Pool().QueryRow(ctx, query, dogID), &dog)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, dogs.ErrDogNotFound
+		}
+		r.dbMetrics.RecordError("select", "dogs", "query")
+		slog.ErrorContext(ctx, "Failed to get dog from PostgreSQL", "id", id, "error", err)
+		return nil, fmt.Errorf("failed to get dog from PostgreSQL: %w", err)
+	}
+
+	return &dog, nil
+}
+
+// ListDogs returns a page of dogs ordered from newest to oldest
+func (r *PostgresRepository) ListDogs(ctx context.Context, filter dogs.ListDogsFilter) (*dogs.DogsPage, error) {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("select", "dogs", time.Since(start))
+	}()
+
+	var (
+		conditions []string
+		args       []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Breed != "" {
+		conditions = append(conditions, "breed = "+arg(filter.Breed))
+	}
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "created_at >= "+arg(filter.From))
+	}
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "created_at < "+arg(filter.To))
+	}
+	if filter.Cursor != "" {
+		cursor, err := dogs.DecodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cursorID, err := strconv.Atoi(cursor.ID)
+		if err != nil {
+			return nil, dogs.ErrInvalidCursor
+		}
+		ts := arg(cursor.CreatedAt)
+		conditions = append(conditions, fmt.Sprintf("(created_at < %s OR (created_at = %s AND id < %s))", ts, ts, arg(cursorID)))
+	}
+
+	query := `
+		SELECT ` + dogColumns + `
+		FROM dogs`
+	if len(conditions) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(conditions, " AND ")
+	}
+	query += "\n\t\tORDER BY created_at DESC, id DESC\n\t\tLIMIT " + arg(filter.Limit+1)
+
+	rows, err := r.postgres.Pool().Query(ctx, query, args...)
+	if err != nil {
+		r.dbMetrics.RecordError("select", "dogs", "query")
+		slog.ErrorContext(ctx, "Failed to list dogs from PostgreSQL", "error", err)
+		return nil, fmt.Errorf("failed to list dogs from PostgreSQL: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]dogs.Dog, 0, filter.Limit+1)
+	for rows.Next() {
+		var dog dogs.Dog
+		if err := scanDog(rows, &dog); err != nil {
+			r.dbMetrics.RecordError("select", "dogs", "scan")
+			return nil, fmt.Errorf("failed to scan dog from PostgreSQL: %w", err)
+		}
+		result = append(result, dog)
+	}
+	if err := rows.Err(); err != nil {
+		r.dbMetrics.RecordError("select", "dogs", "rows")
+		return nil, fmt.Errorf("failed to iterate dogs from PostgreSQL: %w", err)
+	}
+
+	return dogs.NewDogsPage(result, filter.Limit), nil
+}
+
+// uniqueViolation is the PostgreSQL error code of a duplicate key
+const uniqueViolation = "23505"
+
+// wrapError marks a duplicate key as a conflict, a concurrent writer stored the same row first
+func wrapError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return errs.Wrap(errs.Conflict, err)
+	}
+	return err
 }
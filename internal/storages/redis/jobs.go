@@ -0,0 +1,123 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-platform/internal/models/jobs"
+	"go-platform/pkg/cache/redis"
+	"go-platform/pkg/metrics"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	jobKeyPrefix = "jobs:"
+	// unfinishedKeyPrefix holds per instance sets of the IDs of queued and running jobs
+	unfinishedKeyPrefix = "jobs:unfinished:"
+)
+
+type JobsRepositoryMetricsInterface interface {
+	RecordQuery(operation, table string, duration time.Duration)
+	RecordError(operation, table, errorType string)
+}
+
+// JobsRepository keeps job state in Redis so every instance can report it
+type JobsRepository struct {
+	redis     *redis.RedisClient
+	ttl       time.Duration
+	dbMetrics JobsRepositoryMetricsInterface
+}
+
+func NewJobsRepository(redis *redis.RedisClient, ttl time.Duration, dbMetrics *metrics.DatabaseMetrics) *JobsRepository {
+	return &JobsRepository{redis: redis, ttl: ttl, dbMetrics: dbMetrics}
+}
+
+// SaveJob stores the job state and tracks it as unfinished until it reaches a final status
+func (r *JobsRepository) SaveJob(ctx context.Context, job *jobs.Job) error {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("set", "jobs", time.Since(start))
+	}()
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job: %w", err)
+	}
+
+	_, err = r.redis.Client().TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Set(ctx, jobKeyPrefix+job.ID, data, r.ttl)
+		if job.Status.Finished() {
+			pipe.SRem(ctx, unfinishedKeyPrefix+job.Instance, job.ID)
+		} else {
+			pipe.SAdd(ctx, unfinishedKeyPrefix+job.Instance, job.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		r.dbMetrics.RecordError("set", "jobs", "query")
+		return fmt.Errorf("failed to save job into Redis: %w", err)
+	}
+
+	return nil
+}
+
+// GetJob returns the job state by ID
+func (r *JobsRepository) GetJob(ctx context.Context, id string) (*jobs.Job, error) {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("get", "jobs", time.Since(start))
+	}()
+
+	data, err := r.redis.Client().Get(ctx, jobKeyPrefix+id).Bytes()
+	if err != nil {
+		if errors.Is(err, goredis.Nil) {
+			return nil, jobs.ErrJobNotFound
+		}
+		r.dbMetrics.RecordError("get", "jobs", "query")
+		return nil, fmt.Errorf("failed to get job from Redis: %w", err)
+	}
+
+	var job jobs.Job
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// ListUnfinishedJobs returns the queued and running jobs of the instance.
+// IDs of jobs that expired meanwhile are dropped.
+func (r *JobsRepository) ListUnfinishedJobs(ctx context.Context, instance string) ([]*jobs.Job, error) {
+	start := time.Now()
+	defer func() {
+		r.dbMetrics.RecordQuery("smembers", "jobs", time.Since(start))
+	}()
+
+	key := unfinishedKeyPrefix + instance
+	ids, err := r.redis.Client().SMembers(ctx, key).Result()
+	if err != nil {
+		r.dbMetrics.RecordError("smembers", "jobs", "query")
+		return nil, fmt.Errorf("failed to list unfinished jobs from Redis: %w", err)
+	}
+
+	var unfinished []*jobs.Job
+	for _, id := range ids {
+		job, err := r.GetJob(ctx, id)
+		if errors.Is(err, jobs.ErrJobNotFound) {
+			r.redis.Client().SRem(ctx, key, id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !job.Status.Finished() {
+			unfinished = append(unfinished, job)
+		}
+	}
+
+	return unfinished, nil
+}
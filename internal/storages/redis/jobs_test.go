@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"go-platform/internal/models/jobs"
+	"go-platform/pkg/cache/redis"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+type noopMetrics struct{}
+
+func (noopMetrics) RecordQuery(string, string, time.Duration) {}
+func (noopMetrics) RecordError(string, string, string)        {}
+
+func TestListUnfinishedJobs(t *testing.T) {
+	server := miniredis.RunT(t)
+	client, err := redis.NewRedis(context.Background(), server.Addr(), "", 0)
+	if err != nil {
+		t.Fatalf("NewRedis() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	repository := &JobsRepository{redis: client, ttl: time.Hour, dbMetrics: noopMetrics{}}
+	ctx := context.Background()
+
+	saved := []*jobs.Job{
+		{ID: "queued", Status: jobs.StatusQueued, Instance: "app-1"},
+		{ID: "running", Status: jobs.StatusRunning, Instance: "app-1"},
+		{ID: "succeeded", Status: jobs.StatusSucceeded, Instance: "app-1"},
+		{ID: "finished later", Status: jobs.StatusRunning, Instance: "app-1"},
+		{ID: "expired", Status: jobs.StatusQueued, Instance: "app-1"},
+		{ID: "other instance", Status: jobs.StatusQueued, Instance: "app-2"},
+	}
+	for _, job := range saved {
+		if err := repository.SaveJob(ctx, job); err != nil {
+			t.Fatalf("SaveJob() error = %v", err)
+		}
+	}
+	if err := repository.SaveJob(ctx, &jobs.Job{ID: "finished later", Status: jobs.StatusFailed, Instance: "app-1"}); err != nil {
+		t.Fatalf("SaveJob() error = %v", err)
+	}
+	server.Del(jobKeyPrefix + "expired")
+
+	tests := []struct {
+		instance string
+		want     []string
+	}{
+		{instance: "app-1", want: []string{"queued", "running"}},
+		{instance: "app-2", want: []string{"other instance"}},
+		{instance: "app-3", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.instance, func(t *testing.T) {
+			unfinished, err := repository.ListUnfinishedJobs(ctx, tt.instance)
+			if err != nil {
+				t.Fatalf("ListUnfinishedJobs() error = %v", err)
+			}
+			var got []string
+			for _, job := range unfinished {
+				got = append(got, job.ID)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("ListUnfinishedJobs(%q) = %v, want %v", tt.instance, got, tt.want)
+			}
+		})
+	}
+
+	if members, _ := server.SMembers(unfinishedKeyPrefix + "app-1"); slices.Contains(members, "expired") {
+		t.Fatalf("expired job is still tracked as unfinished: %v", members)
+	}
+}
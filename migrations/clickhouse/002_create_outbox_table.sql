@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ClickHouse has no transactions and no cheap updates: state changes are new row
+-- versions collapsed by ReplacingMergeTree, readers use FINAL
+CREATE TABLE IF NOT EXISTS outbox (
+    id String,
+    event_type String,
+    payload String,
+    headers String,
+    attempts UInt32 DEFAULT 0,
+    last_error String DEFAULT '',
+    created_at DateTime64(3) DEFAULT now64(),
+    published_at Nullable(DateTime64(3)),
+    version UInt64 DEFAULT 0
+) ENGINE = ReplacingMergeTree(version)
+ORDER BY id
+TTL toDateTime(created_at) + INTERVAL 7 DAY DELETE WHERE published_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS outbox;
+-- +goose StatementEnd
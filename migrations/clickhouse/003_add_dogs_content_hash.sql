@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- SHA-256 of the image bytes; empty for rows archived before content addressing.
+-- ClickHouse has no unique constraints, the repository looks the hash up before inserting
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS content_hash String DEFAULT '';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE dogs ADD INDEX IF NOT EXISTS idx_dogs_content_hash content_hash TYPE bloom_filter GRANULARITY 4;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE dogs MATERIALIZE INDEX idx_dogs_content_hash;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dogs DROP INDEX IF EXISTS idx_dogs_content_hash;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE dogs DROP COLUMN IF EXISTS content_hash;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Presigned URLs expire, so the object key is stored and the URL is generated when served
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS image_key String DEFAULT '';
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Existing rows get the key from the trailing dogs/{breed}/{name} of their public URL
+ALTER TABLE dogs UPDATE image_key = extract(image_url, 'dogs/[^/]+/[^/]+$') WHERE image_key = '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dogs DROP COLUMN IF EXISTS image_key;
+-- +goose StatementEnd
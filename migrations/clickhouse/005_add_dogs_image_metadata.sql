@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Probed dimensions and MIME type of the original and the S3 keys of its JPEG renditions
+ALTER TABLE dogs
+    ADD COLUMN IF NOT EXISTS width UInt32 DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS height UInt32 DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS mime_type LowCardinality(String) DEFAULT '',
+    ADD COLUMN IF NOT EXISTS thumbnail_key String DEFAULT '',
+    ADD COLUMN IF NOT EXISTS medium_key String DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dogs
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS mime_type,
+    DROP COLUMN IF EXISTS thumbnail_key,
+    DROP COLUMN IF EXISTS medium_key;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Breed catalog synced from the dog API, a breed without sub-breeds has a single row with an empty sub_breed.
+-- Every sync inserts the whole catalog with a new synced_at: ReplacingMergeTree keeps the latest version
+-- of a row and readers only take rows of the latest sync, so removed breeds drop out without deletes
+CREATE TABLE IF NOT EXISTS breeds (
+    name String,
+    sub_breed String DEFAULT '',
+    synced_at DateTime64(3) DEFAULT now64()
+) ENGINE = ReplacingMergeTree(synced_at)
+ORDER BY (name, sub_breed);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS breeds;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Sub-breed of the dog, e.g. afghan for hound/afghan, empty when the breed has none
+ALTER TABLE dogs
+    ADD COLUMN IF NOT EXISTS sub_breed LowCardinality(String) DEFAULT '' AFTER breed;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dogs
+    DROP COLUMN IF EXISTS sub_breed;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- next_attempt_at hides claimed and failed messages from the relay until then,
+-- dead_at parks messages that failed OUTBOX_MAX_ATTEMPTS times
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS next_attempt_at Nullable(DateTime64(3)) AFTER last_error,
+    ADD COLUMN IF NOT EXISTS dead_at Nullable(DateTime64(3)) AFTER published_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS dead_at,
+    DROP COLUMN IF EXISTS next_attempt_at;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- SHA-256 of the image bytes; NULL for rows archived before content addressing
+ALTER TABLE dogs
+    ADD COLUMN content_hash CHAR(64) NULL,
+    ADD UNIQUE INDEX idx_dogs_content_hash (content_hash);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dogs
+    DROP INDEX idx_dogs_content_hash,
+    DROP COLUMN content_hash;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Presigned URLs expire, so the object key is stored and the URL is generated when served
+ALTER TABLE dogs ADD COLUMN image_key VARCHAR(512) NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Existing rows get the key from the trailing dogs/{breed}/{name} of their public URL
+UPDATE dogs SET image_key = REGEXP_SUBSTR(image_url, 'dogs/[^/]+/[^/]+$') WHERE image_key IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dogs DROP COLUMN image_key;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Probed dimensions and MIME type of the original and the S3 keys of its JPEG renditions
+ALTER TABLE dogs
+    ADD COLUMN width INT NULL,
+    ADD COLUMN height INT NULL,
+    ADD COLUMN mime_type VARCHAR(32) NULL,
+    ADD COLUMN thumbnail_key VARCHAR(512) NULL,
+    ADD COLUMN medium_key VARCHAR(512) NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dogs
+    DROP COLUMN width,
+    DROP COLUMN height,
+    DROP COLUMN mime_type,
+    DROP COLUMN thumbnail_key,
+    DROP COLUMN medium_key;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Breed catalog synced from the dog API, a breed without sub-breeds has a single row with an empty sub_breed
+CREATE TABLE IF NOT EXISTS breeds (
+    name VARCHAR(100) NOT NULL,
+    sub_breed VARCHAR(100) NOT NULL DEFAULT '',
+    synced_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    PRIMARY KEY (name, sub_breed)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS breeds;
+-- +goose StatementEnd
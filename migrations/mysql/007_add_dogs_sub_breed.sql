@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Sub-breed of the dog, e.g. afghan for hound/afghan, empty when the breed has none
+ALTER TABLE dogs
+    ADD COLUMN sub_breed VARCHAR(100) NOT NULL DEFAULT '' AFTER breed,
+    ADD INDEX idx_dogs_breed_sub_breed (breed, sub_breed);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dogs
+    DROP INDEX idx_dogs_breed_sub_breed,
+    DROP COLUMN sub_breed;
+-- +goose StatementEnd
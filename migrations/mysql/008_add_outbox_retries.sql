@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- next_attempt_at hides claimed and failed messages from the relay until then,
+-- dead_at parks messages that failed OUTBOX_MAX_ATTEMPTS times
+ALTER TABLE outbox
+    ADD COLUMN next_attempt_at TIMESTAMP(3) NULL AFTER attempts,
+    ADD COLUMN dead_at TIMESTAMP(3) NULL AFTER published_at,
+    DROP INDEX idx_outbox_pending,
+    ADD INDEX idx_outbox_pending (published_at, dead_at, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE outbox
+    DROP INDEX idx_outbox_pending,
+    ADD INDEX idx_outbox_pending (published_at, created_at),
+    DROP COLUMN dead_at,
+    DROP COLUMN next_attempt_at;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- SHA-256 of the image bytes; NULL for rows archived before content addressing
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS content_hash CHAR(64);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dogs_content_hash ON dogs (content_hash);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_dogs_content_hash;
+ALTER TABLE dogs DROP COLUMN IF EXISTS content_hash;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Presigned URLs expire, so the object key is stored and the URL is generated when served.
+-- Existing rows get the key from the trailing dogs/{breed}/{name} of their public URL
+ALTER TABLE dogs ADD COLUMN IF NOT EXISTS image_key TEXT;
+UPDATE dogs SET image_key = substring(image_url from 'dogs/[^/]+/[^/]+$') WHERE image_key IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dogs DROP COLUMN IF EXISTS image_key;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Probed dimensions and MIME type of the original and the S3 keys of its JPEG renditions
+ALTER TABLE dogs
+    ADD COLUMN IF NOT EXISTS width INT,
+    ADD COLUMN IF NOT EXISTS height INT,
+    ADD COLUMN IF NOT EXISTS mime_type VARCHAR(32),
+    ADD COLUMN IF NOT EXISTS thumbnail_key TEXT,
+    ADD COLUMN IF NOT EXISTS medium_key TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dogs
+    DROP COLUMN IF EXISTS width,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS mime_type,
+    DROP COLUMN IF EXISTS thumbnail_key,
+    DROP COLUMN IF EXISTS medium_key;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Sub-breed of the dog, e.g. afghan for hound/afghan, empty when the breed has none
+ALTER TABLE dogs
+    ADD COLUMN IF NOT EXISTS sub_breed VARCHAR(100) NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_dogs_breed_sub_breed ON dogs (breed, sub_breed);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_dogs_breed_sub_breed;
+ALTER TABLE dogs
+    DROP COLUMN IF EXISTS sub_breed;
+-- +goose StatementEnd
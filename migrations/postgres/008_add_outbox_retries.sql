@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- next_attempt_at hides claimed and failed messages from the relay until then,
+-- dead_at parks messages that failed OUTBOX_MAX_ATTEMPTS times
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS dead_at TIMESTAMP WITH TIME ZONE;
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (created_at) WHERE published_at IS NULL AND dead_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_dead ON outbox (dead_at) WHERE dead_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_outbox_dead;
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (created_at) WHERE published_at IS NULL;
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS dead_at,
+    DROP COLUMN IF EXISTS next_attempt_at;
+-- +goose StatementEnd
@@ -0,0 +1,138 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"go-platform/pkg/tracer"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Event headers set on every published domain event
+const (
+	HeaderContentType   = "Content-Type"
+	HeaderEventType     = "Event-Type"
+	HeaderSchemaVersion = "Event-Schema-Version"
+)
+
+// Event is a versioned domain event published as JSON.
+// EventType is also used as the subject suffix, e.g. "dog.image.archived".
+type Event interface {
+	EventType() string
+	SchemaVersion() int
+}
+
+// Subject returns the full subject for the event type with the configured prefix
+func (n *NATSClient) Subject(eventType string) string {
+	if n.subjectPrefix == "" {
+		return eventType
+	}
+	return n.subjectPrefix + "." + eventType
+}
+
+// Message is an encoded event ready to be published, e.g. restored from the outbox.
+// ID is sent as Nats-Msg-Id so JetStream drops duplicates of retried publishes.
+type Message struct {
+	ID        string            `json:"id"`
+	EventType string            `json:"event_type"`
+	Data      []byte            `json:"data"`
+	Headers   map[string]string `json:"headers"`
+}
+
+// NewMessage encodes the event with its schema headers and the trace context from ctx
+func NewMessage(ctx context.Context, event Event) (*Message, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	headers := map[string]string{
+		HeaderContentType:   "application/json",
+		HeaderEventType:     event.EventType(),
+		HeaderSchemaVersion: strconv.Itoa(event.SchemaVersion()),
+	}
+
+	// inject through HeaderCarrier so the keys are canonical like the ones read by ContextFromHeader
+	traceHeaders := http.Header{}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(traceHeaders))
+	for key := range traceHeaders {
+		headers[key] = traceHeaders.Get(key)
+	}
+
+	return &Message{
+		ID:        uuid.New().String(),
+		EventType: event.EventType(),
+		Data:      data,
+		Headers:   headers,
+	}, nil
+}
+
+// PublishEvent publishes the event with its schema headers and the trace context from ctx
+func (n *NATSClient) PublishEvent(ctx context.Context, event Event) error {
+	msg, err := NewMessage(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	return n.PublishMessage(ctx, msg)
+}
+
+// PublishMessage publishes an already encoded event
+func (n *NATSClient) PublishMessage(ctx context.Context, msg *Message) (err error) {
+	natsMsg := n.natsMsg(msg)
+	_, span := startPublishSpan(ctx, natsMsg)
+	defer func() { tracer.EndSpan(span, err) }()
+
+	if err := n.conn.PublishMsg(natsMsg); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	return nil
+}
+
+func (n *NATSClient) natsMsg(msg *Message) *nats.Msg {
+	natsMsg := nats.NewMsg(n.Subject(msg.EventType))
+	natsMsg.Data = msg.Data
+	// canonical keys, messages stored in the outbox before may carry a lowercase traceparent
+	for key, value := range msg.Headers {
+		natsMsg.Header.Set(http.CanonicalHeaderKey(key), value)
+	}
+	natsMsg.Header.Set(nats.MsgIdHdr, msg.ID)
+
+	return natsMsg
+}
+
+// startPublishSpan starts the producer span of msg as a child of the trace carried in its headers,
+// so messages relayed from the outbox join the trace of the request that stored them.
+// The headers are updated to make the consumer a child of the producer span.
+func startPublishSpan(ctx context.Context, msg *nats.Msg) (context.Context, trace.Span) {
+	ctx, span := tracer.StartSpan(ContextFromHeader(ctx, msg.Header), msg.Subject+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("nats"),
+			semconv.MessagingOperationPublish,
+			semconv.MessagingDestinationName(msg.Subject),
+			semconv.MessagingMessageID(msg.Header.Get(nats.MsgIdHdr)),
+		),
+	)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(msg.Header))
+
+	return ctx, span
+}
+
+// ContextFromHeader returns ctx with the trace context carried in the message headers
+func ContextFromHeader(ctx context.Context, header nats.Header) context.Context {
+	if header == nil {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
@@ -0,0 +1,67 @@
+package nats
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceContextRoundTrip(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), parent)
+
+	encoded, err := NewMessage(ctx, testEvent{Name: "traced"})
+	if err != nil {
+		t.Fatalf("NewMessage() error = %v", err)
+	}
+	legacy := *encoded
+	legacy.Headers = map[string]string{"traceparent": encoded.Headers["Traceparent"]}
+
+	tests := []struct {
+		name string
+		msg  *Message
+	}{
+		{name: "new message", msg: encoded},
+		{name: "lowercase key stored in the outbox", msg: &legacy},
+	}
+
+	client := &NATSClient{subjectPrefix: "test"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			natsMsg := client.natsMsg(tt.msg)
+
+			if _, ok := natsMsg.Header["traceparent"]; ok {
+				t.Errorf("header has a non canonical traceparent key: %v", natsMsg.Header)
+			}
+			if got := len(natsMsg.Header.Values("Traceparent")); got != 1 {
+				t.Errorf("header has %d Traceparent values, want 1", got)
+			}
+
+			got := trace.SpanContextFromContext(ContextFromHeader(context.Background(), natsMsg.Header))
+			if !got.IsValid() {
+				t.Fatalf("extracted span context is invalid, headers %v", natsMsg.Header)
+			}
+			if got.TraceID() != parent.TraceID() || got.SpanID() != parent.SpanID() {
+				t.Fatalf("extracted %s/%s, want %s/%s", got.TraceID(), got.SpanID(), parent.TraceID(), parent.SpanID())
+			}
+
+			// the publish span continues the same trace
+			_, span := startPublishSpan(context.Background(), natsMsg)
+			defer span.End()
+			child := trace.SpanContextFromContext(ContextFromHeader(context.Background(), natsMsg.Header))
+			if child.TraceID() != parent.TraceID() {
+				t.Fatalf("publish span trace %s, want %s", child.TraceID(), parent.TraceID())
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"go-platform/pkg/config"
+	"go-platform/pkg/tracer"
+)
+
+// Dead letter headers added to messages routed to the dead letter stream
+const (
+	HeaderDeadLetterReason     = "Dead-Letter-Reason"
+	HeaderDeadLetterSubject    = "Dead-Letter-Subject"
+	HeaderDeadLetterStream     = "Dead-Letter-Stream"
+	HeaderDeadLetterConsumer   = "Dead-Letter-Consumer"
+	HeaderDeadLetterDeliveries = "Dead-Letter-Deliveries"
+)
+
+// ErrPermanent marks handler errors that must not be retried
+var ErrPermanent = errors.New("permanent failure")
+
+// Permanent wraps err so the message is dead-lettered right away instead of redelivered
+func Permanent(err error) error {
+	return fmt.Errorf("%w: %w", ErrPermanent, err)
+}
+
+// MsgHandler processes a JetStream message. Returning nil acks the message,
+// an error naks it with backoff until MaxDeliver is reached and then dead-letters it.
+// Errors wrapped with Permanent are dead-lettered on the first delivery.
+type MsgHandler func(ctx context.Context, msg jetstream.Msg) error
+
+// JetStream provides persistent publishing and durable pull consumers on top of NATSClient
+type JetStream struct {
+	client *NATSClient
+	js     jetstream.JetStream
+	cfg    config.JetStreamConfig
+}
+
+// NewJetStream creates the JetStream context and provisions the main and dead letter streams
+func NewJetStream(ctx context.Context, client *NATSClient, cfg config.JetStreamConfig) (*JetStream, error) {
+	if client.subjectPrefix == "" {
+		return nil, fmt.Errorf("subject prefix is required for JetStream")
+	}
+
+	js, err := jetstream.New(client.conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	j := &JetStream{client: client, js: js, cfg: cfg}
+	if err := j.provisionStreams(ctx); err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+func (j *JetStream) provisionStreams(ctx context.Context) error {
+	_, err := j.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     j.cfg.StreamName,
+		Subjects: []string{j.client.Subject(">")},
+		Storage:  jetstream.FileStorage,
+		MaxAge:   j.cfg.StreamMaxAge,
+		Replicas: j.cfg.Replicas,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to provision stream %s: %w", j.cfg.StreamName, err)
+	}
+
+	_, err = j.js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     j.deadLetterStream(),
+		Subjects: []string{j.cfg.DeadLetterPrefix + ".>"},
+		Storage:  jetstream.FileStorage,
+		MaxAge:   j.cfg.DeadLetterMaxAge,
+		Replicas: j.cfg.Replicas,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to provision stream %s: %w", j.deadLetterStream(), err)
+	}
+
+	slog.Info("JetStream streams provisioned", "stream", j.cfg.StreamName, "dead_letter_stream", j.deadLetterStream())
+	return nil
+}
+
+func (j *JetStream) deadLetterStream() string {
+	return j.cfg.StreamName + "_DLQ"
+}
+
+// PublishEvent publishes the event and waits for the stream acknowledgement
+func (j *JetStream) PublishEvent(ctx context.Context, event Event) error {
+	msg, err := NewMessage(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	return j.PublishMessage(ctx, msg)
+}
+
+// PublishMessage publishes an already encoded event and waits for the stream acknowledgement
+func (j *JetStream) PublishMessage(ctx context.Context, msg *Message) (err error) {
+	natsMsg := j.client.natsMsg(msg)
+	ctx, span := startPublishSpan(ctx, natsMsg)
+	defer func() { tracer.EndSpan(span, err) }()
+
+	if _, err := j.js.PublishMsg(ctx, natsMsg); err != nil {
+		return fmt.Errorf("failed to publish event to JetStream: %w", err)
+	}
+
+	return nil
+}
+
+// Consume provisions a durable pull consumer for the event type and starts processing messages.
+// Stop the returned ConsumeContext to stop consuming.
+func (j *JetStream) Consume(ctx context.Context, durable, eventType string, handler MsgHandler) (jetstream.ConsumeContext, error) {
+	consumer, err := j.js.CreateOrUpdateConsumer(ctx, j.cfg.StreamName, jetstream.ConsumerConfig{
+		Durable:       durable,
+		FilterSubject: j.client.Subject(eventType),
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       j.cfg.AckWait,
+		MaxDeliver:    j.cfg.MaxDeliver,
+		BackOff:       j.backoffSchedule(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to provision consumer %s: %w", durable, err)
+	}
+
+	consumeCtx, err := consumer.Consume(func(msg jetstream.Msg) {
+		j.handle(ctx, durable, msg, handler)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start consumer %s: %w", durable, err)
+	}
+
+	slog.Info("JetStream consumer started", "consumer", durable, "subject", j.client.Subject(eventType))
+	return consumeCtx, nil
+}
+
+func (j *JetStream) handle(ctx context.Context, durable string, msg jetstream.Msg, handler MsgHandler) {
+	meta, err := msg.Metadata()
+	if err != nil {
+		slog.Error("Failed to read message metadata", "consumer", durable, "subject", msg.Subject(), "error", err)
+		if err := msg.Nak(); err != nil {
+			slog.Error("Failed to nak message", "consumer", durable, "error", err)
+		}
+		return
+	}
+
+	// the consumer span also covers the acknowledgement and a dead-letter publish
+	ctx, span := tracer.StartSpan(ContextFromHeader(ctx, msg.Headers()), msg.Subject()+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("nats"),
+			semconv.MessagingOperationProcess,
+			semconv.MessagingDestinationName(msg.Subject()),
+			semconv.MessagingMessageID(msg.Headers().Get(nats.MsgIdHdr)),
+			attribute.String("messaging.consumer.group.name", durable),
+			attribute.Int64("messaging.nats.delivery_count", int64(meta.NumDelivered)),
+		),
+	)
+	defer span.End()
+
+	err = handler(ctx, msg)
+	if err == nil {
+		if err := msg.Ack(); err != nil {
+			slog.Error("Failed to ack message", "consumer", durable, "subject", msg.Subject(), "error", err)
+		}
+		return
+	}
+
+	tracer.SetSpanError(span, err)
+
+	deliveries := meta.NumDelivered
+	if errors.Is(err, ErrPermanent) || (j.cfg.MaxDeliver > 0 && deliveries >= uint64(j.cfg.MaxDeliver)) {
+		j.deadLetter(ctx, durable, msg, deliveries, err)
+		return
+	}
+
+	delay := j.backoff(deliveries)
+	slog.Warn("Message processing failed, will retry",
+		"consumer", durable,
+		"subject", msg.Subject(),
+		"delivery", deliveries,
+		"retry_in", delay,
+		"error", err,
+	)
+	if err := msg.NakWithDelay(delay); err != nil {
+		slog.Error("Failed to nak message", "consumer", durable, "error", err)
+	}
+}
+
+// deadLetter copies the message to the dead letter stream and terminates it.
+// If the copy fails the message is redelivered later so it is never lost.
+func (j *JetStream) deadLetter(ctx context.Context, durable string, msg jetstream.Msg, deliveries uint64, cause error) {
+	dlq := nats.NewMsg(j.cfg.DeadLetterPrefix + "." + msg.Subject())
+	dlq.Data = msg.Data()
+	for key, values := range msg.Headers() {
+		for _, value := range values {
+			dlq.Header.Add(key, value)
+		}
+	}
+	dlq.Header.Set(HeaderDeadLetterReason, cause.Error())
+	dlq.Header.Set(HeaderDeadLetterSubject, msg.Subject())
+	dlq.Header.Set(HeaderDeadLetterStream, j.cfg.StreamName)
+	dlq.Header.Set(HeaderDeadLetterConsumer, durable)
+	dlq.Header.Set(HeaderDeadLetterDeliveries, strconv.FormatUint(deliveries, 10))
+
+	if _, err := j.js.PublishMsg(ctx, dlq); err != nil {
+		slog.Error("Failed to dead-letter message", "consumer", durable, "subject", msg.Subject(), "error", err)
+		if err := msg.NakWithDelay(j.backoff(deliveries)); err != nil {
+			slog.Error("Failed to nak message", "consumer", durable, "error", err)
+		}
+		return
+	}
+
+	slog.Error("Message dead-lettered",
+		"consumer", durable,
+		"subject", msg.Subject(),
+		"dead_letter_subject", dlq.Subject,
+		"deliveries", deliveries,
+		"error", cause,
+	)
+	if err := msg.TermWithReason(cause.Error()); err != nil {
+		slog.Error("Failed to terminate message", "consumer", durable, "error", err)
+	}
+}
+
+// backoff returns the redelivery delay after the given number of deliveries: base * 2^(n-1) capped at max
+func (j *JetStream) backoff(deliveries uint64) time.Duration {
+	delay := j.cfg.BackoffBase
+	for i := uint64(1); i < deliveries && delay < j.cfg.BackoffMax; i++ {
+		delay *= 2
+	}
+	return min(delay, j.cfg.BackoffMax)
+}
+
+// backoffSchedule is used by the server for messages not acked within AckWait.
+// It holds one delay per redelivery, MaxDeliver-1 entries, as the server rejects
+// consumers whose MaxDeliver does not exceed the schedule length.
+func (j *JetStream) backoffSchedule() []time.Duration {
+	if j.cfg.MaxDeliver <= 1 || j.cfg.BackoffBase <= 0 {
+		return nil
+	}
+
+	schedule := make([]time.Duration, 0, j.cfg.MaxDeliver-1)
+	for i := 1; i < j.cfg.MaxDeliver; i++ {
+		schedule = append(schedule, max(j.backoff(uint64(i)), j.cfg.AckWait))
+	}
+	return schedule
+}
@@ -0,0 +1,313 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"go-platform/pkg/config"
+)
+
+type testEvent struct {
+	Name string `json:"name"`
+}
+
+func (testEvent) EventType() string  { return "test.happened" }
+func (testEvent) SchemaVersion() int { return 1 }
+
+func testJetStreamConfig() config.JetStreamConfig {
+	return config.JetStreamConfig{
+		Enabled:          true,
+		StreamName:       "TEST",
+		StreamMaxAge:     time.Hour,
+		Replicas:         1,
+		AckWait:          time.Second,
+		MaxDeliver:       3,
+		BackoffBase:      20 * time.Millisecond,
+		BackoffMax:       50 * time.Millisecond,
+		DeadLetterPrefix: "test-dlq",
+		DeadLetterMaxAge: time.Hour,
+	}
+}
+
+// newTestJetStream starts an in-process JetStream server and connects to it
+func newTestJetStream(t *testing.T, cfg config.JetStreamConfig) *JetStream {
+	t.Helper()
+
+	opts := natsserver.DefaultTestOptions
+	opts.Port = -1
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	server := natsserver.RunServer(&opts)
+	t.Cleanup(server.Shutdown)
+
+	ctx := context.Background()
+	client, err := NewNATS(ctx, server.ClientURL(), "test")
+	if err != nil {
+		t.Fatalf("NewNATS() error = %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	js, err := NewJetStream(ctx, client, cfg)
+	if err != nil {
+		t.Fatalf("NewJetStream() error = %v", err)
+	}
+	return js
+}
+
+// delivery is a message seen by a test handler
+type delivery struct {
+	count uint64
+	at    time.Time
+}
+
+// recordingHandler records deliveries and fails the first failures of them with err
+func recordingHandler(failures int, err error) (MsgHandler, func() []delivery) {
+	var (
+		mu         sync.Mutex
+		deliveries []delivery
+	)
+	handler := func(_ context.Context, msg jetstream.Msg) error {
+		meta, metaErr := msg.Metadata()
+		if metaErr != nil {
+			return metaErr
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		deliveries = append(deliveries, delivery{count: meta.NumDelivered, at: time.Now()})
+		if len(deliveries) <= failures {
+			return err
+		}
+		return nil
+	}
+	seen := func() []delivery {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]delivery(nil), deliveries...)
+	}
+	return handler, seen
+}
+
+func waitFor(t *testing.T, timeout time.Duration, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before timeout")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestBackoffSchedule(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.JetStreamConfig
+		want []time.Duration
+	}{
+		{
+			name: "defaults",
+			cfg:  config.JetStreamConfig{MaxDeliver: 5, BackoffBase: time.Second, BackoffMax: time.Minute, AckWait: 30 * time.Second},
+			want: []time.Duration{30 * time.Second, 30 * time.Second, 30 * time.Second, 30 * time.Second},
+		},
+		{
+			name: "exponential above ack wait",
+			cfg:  config.JetStreamConfig{MaxDeliver: 5, BackoffBase: time.Second, BackoffMax: 5 * time.Second, AckWait: time.Second},
+			want: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second},
+		},
+		{
+			name: "single delivery",
+			cfg:  config.JetStreamConfig{MaxDeliver: 1, BackoffBase: time.Second, BackoffMax: time.Minute},
+			want: nil,
+		},
+		{
+			name: "unlimited deliveries",
+			cfg:  config.JetStreamConfig{MaxDeliver: -1, BackoffBase: time.Second, BackoffMax: time.Minute},
+			want: nil,
+		},
+		{
+			name: "no backoff",
+			cfg:  config.JetStreamConfig{MaxDeliver: 5},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := (&JetStream{cfg: tt.cfg}).backoffSchedule()
+			if len(got) != len(tt.want) {
+				t.Fatalf("backoffSchedule() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("backoffSchedule() = %v, want %v", got, tt.want)
+				}
+			}
+			if tt.cfg.MaxDeliver > 0 && len(got) >= tt.cfg.MaxDeliver {
+				t.Fatalf("backoffSchedule() has %d entries, the server requires fewer than MaxDeliver %d", len(got), tt.cfg.MaxDeliver)
+			}
+		})
+	}
+}
+
+func TestJetStreamConsumerWithDefaultConfig(t *testing.T) {
+	cfg := testJetStreamConfig()
+	cfg.AckWait = 30 * time.Second
+	cfg.MaxDeliver = 5
+	cfg.BackoffBase = time.Second
+	cfg.BackoffMax = time.Minute
+	js := newTestJetStream(t, cfg)
+
+	handler, _ := recordingHandler(0, nil)
+	consumeCtx, err := js.Consume(context.Background(), "defaults", testEvent{}.EventType(), handler)
+	if err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+	consumeCtx.Stop()
+}
+
+func TestJetStreamPublishConsume(t *testing.T) {
+	js := newTestJetStream(t, testJetStreamConfig())
+	ctx := context.Background()
+
+	handler, seen := recordingHandler(0, nil)
+	consumeCtx, err := js.Consume(ctx, "consume", testEvent{}.EventType(), handler)
+	if err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+	defer consumeCtx.Stop()
+
+	if err := js.PublishEvent(ctx, testEvent{Name: "first"}); err != nil {
+		t.Fatalf("PublishEvent() error = %v", err)
+	}
+	waitFor(t, 5*time.Second, func() bool { return len(seen()) == 1 })
+
+	// an acked message is not redelivered
+	time.Sleep(2 * testJetStreamConfig().AckWait)
+	if got := seen(); len(got) != 1 {
+		t.Fatalf("got %d deliveries, want 1", len(got))
+	}
+}
+
+func TestJetStreamPublishDeduplicates(t *testing.T) {
+	js := newTestJetStream(t, testJetStreamConfig())
+	ctx := context.Background()
+
+	msg, err := NewMessage(ctx, testEvent{Name: "once"})
+	if err != nil {
+		t.Fatalf("NewMessage() error = %v", err)
+	}
+	for range 2 {
+		if err := js.PublishMessage(ctx, msg); err != nil {
+			t.Fatalf("PublishMessage() error = %v", err)
+		}
+	}
+
+	stream, err := js.js.Stream(ctx, testJetStreamConfig().StreamName)
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	info, err := stream.Info(ctx)
+	if err != nil {
+		t.Fatalf("Info() error = %v", err)
+	}
+	if info.State.Msgs != 1 {
+		t.Fatalf("stream has %d messages, want 1", info.State.Msgs)
+	}
+}
+
+func TestJetStreamNakBackoff(t *testing.T) {
+	cfg := testJetStreamConfig()
+	js := newTestJetStream(t, cfg)
+	ctx := context.Background()
+
+	handler, seen := recordingHandler(2, errors.New("temporary"))
+	consumeCtx, err := js.Consume(ctx, "retry", testEvent{}.EventType(), handler)
+	if err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+	defer consumeCtx.Stop()
+
+	if err := js.PublishEvent(ctx, testEvent{Name: "retried"}); err != nil {
+		t.Fatalf("PublishEvent() error = %v", err)
+	}
+	waitFor(t, 5*time.Second, func() bool { return len(seen()) == 3 })
+
+	got := seen()
+	for i, d := range got {
+		if d.count != uint64(i+1) {
+			t.Fatalf("delivery %d has count %d, want %d", i, d.count, i+1)
+		}
+		if i == 0 {
+			continue
+		}
+		if wait, want := d.at.Sub(got[i-1].at), js.backoff(got[i-1].count); wait < want {
+			t.Fatalf("delivery %d came after %v, want at least %v", i+1, wait, want)
+		}
+	}
+}
+
+func TestJetStreamDeadLetter(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantDeliveries int
+	}{
+		{name: "max deliver reached", err: errors.New("temporary"), wantDeliveries: 3},
+		{name: "permanent failure", err: Permanent(errors.New("malformed")), wantDeliveries: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testJetStreamConfig()
+			js := newTestJetStream(t, cfg)
+			ctx := context.Background()
+
+			handler, seen := recordingHandler(cfg.MaxDeliver, tt.err)
+			consumeCtx, err := js.Consume(ctx, "dead-letter", testEvent{}.EventType(), handler)
+			if err != nil {
+				t.Fatalf("Consume() error = %v", err)
+			}
+			defer consumeCtx.Stop()
+
+			if err := js.PublishEvent(ctx, testEvent{Name: "failing"}); err != nil {
+				t.Fatalf("PublishEvent() error = %v", err)
+			}
+
+			stream, err := js.js.Stream(ctx, js.deadLetterStream())
+			if err != nil {
+				t.Fatalf("Stream() error = %v", err)
+			}
+			subject := js.client.Subject(testEvent{}.EventType())
+			var dead *jetstream.RawStreamMsg
+			waitFor(t, 5*time.Second, func() bool {
+				dead, err = stream.GetLastMsgForSubject(ctx, cfg.DeadLetterPrefix+"."+subject)
+				return err == nil
+			})
+
+			if got := dead.Header.Get(HeaderDeadLetterSubject); got != subject {
+				t.Errorf("%s = %q, want %q", HeaderDeadLetterSubject, got, subject)
+			}
+			if got := dead.Header.Get(HeaderDeadLetterConsumer); got != "dead-letter" {
+				t.Errorf("%s = %q, want %q", HeaderDeadLetterConsumer, got, "dead-letter")
+			}
+			if got := dead.Header.Get(HeaderDeadLetterReason); got != tt.err.Error() {
+				t.Errorf("%s = %q, want %q", HeaderDeadLetterReason, got, tt.err.Error())
+			}
+			if got, want := dead.Header.Get(HeaderEventType), (testEvent{}).EventType(); got != want {
+				t.Errorf("%s = %q, want %q", HeaderEventType, got, want)
+			}
+
+			// the terminated message is not redelivered
+			time.Sleep(2 * cfg.AckWait)
+			if got := len(seen()); got != tt.wantDeliveries {
+				t.Fatalf("got %d deliveries, want %d", got, tt.wantDeliveries)
+			}
+		})
+	}
+}
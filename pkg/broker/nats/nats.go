@@ -4,16 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/nats-io/nats.go"
 )
 
 type NATSClient struct {
-	conn *nats.Conn
+	conn          *nats.Conn
+	subjectPrefix string
 }
 
-func NewNATS(ctx context.Context, url string) (*NATSClient, error) {
+func NewNATS(ctx context.Context, url, subjectPrefix string) (*NATSClient, error) {
 	opts := []nats.Option{
 		nats.Name("wb-app"),
 		nats.Timeout(10 * time.Second),
@@ -33,7 +35,7 @@ func NewNATS(ctx context.Context, url string) (*NATSClient, error) {
 		return nil, fmt.Errorf("failed to establish NATS connection")
 	}
 
-	return &NATSClient{conn: conn}, nil
+	return &NATSClient{conn: conn, subjectPrefix: subjectPrefix}, nil
 }
 
 func (n *NATSClient) Close() {
@@ -57,16 +59,14 @@ func (n *NATSClient) PublishOrder(subject string, order interface{}) error {
 	return nil
 }
 
-// SubscribeToOrders subscribes to orders on the specified subject
+// SubscribeToOrders subscribes to orders on the specified subject.
+// Delivery is at-most-once: a failed handler call drops the message.
 func (n *NATSClient) SubscribeToOrders(subject string, handler func([]byte) error) (*nats.Subscription, error) {
 	sub, err := n.conn.Subscribe(subject, func(msg *nats.Msg) {
 		if err := handler(msg.Data); err != nil {
-			// Log error but don't ack the message to allow retry
-			fmt.Printf("Error processing message: %v\n", err)
-			return
+			// Core NATS has no redelivery, use JetStream.Consume when retries matter
+			slog.Error("Error processing message", "subject", msg.Subject, "error", err)
 		}
-		// Acknowledge the message
-		msg.Ack()
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to subscribe: %w", err)
@@ -79,12 +79,9 @@ func (n *NATSClient) SubscribeToOrders(subject string, handler func([]byte) erro
 func (n *NATSClient) SubscribeToOrdersWithQueue(subject, queueGroup string, handler func([]byte) error) (*nats.Subscription, error) {
 	sub, err := n.conn.QueueSubscribe(subject, queueGroup, func(msg *nats.Msg) {
 		if err := handler(msg.Data); err != nil {
-			// Log error but don't ack the message to allow retry
-			fmt.Printf("Error processing message: %v\n", err)
-			return
+			// Core NATS has no redelivery, use JetStream.Consume when retries matter
+			slog.Error("Error processing message", "subject", msg.Subject, "error", err)
 		}
-		// Acknowledge the message
-		msg.Ack()
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to subscribe with queue: %w", err)
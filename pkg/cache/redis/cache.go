@@ -0,0 +1,168 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+// negativeValue marks a cached "known missing" result
+const negativeValue = "\x00negative"
+
+// loadTimeout bounds a shared load, it no longer follows the context of a single caller
+const loadTimeout = 30 * time.Second
+
+type CacheMetricsInterface interface {
+	RecordHit(family string)
+	RecordMiss(family string)
+	RecordEviction(family string, count int)
+}
+
+// Family describes a group of keys sharing the same prefix and TTL
+type Family struct {
+	Name string
+	TTL  time.Duration
+	// NegativeErr is cached for NegativeTTL when the loader returns it (errors.Is).
+	// Zero NegativeTTL disables negative caching for the family.
+	NegativeErr error
+	NegativeTTL time.Duration
+}
+
+// Cache is a JSON read-through cache on top of Redis.
+// Concurrent misses for the same key are collapsed into a single load.
+type Cache struct {
+	client  *RedisClient
+	prefix  string
+	metrics CacheMetricsInterface
+	group   singleflight.Group
+}
+
+func NewCache(client *RedisClient, prefix string, metrics CacheMetricsInterface) *Cache {
+	return &Cache{client: client, prefix: prefix, metrics: metrics}
+}
+
+func (c *Cache) key(family Family, key string) string {
+	return c.prefix + ":" + family.Name + ":" + key
+}
+
+// GetOrLoad returns the cached value for key or calls load and caches its result.
+// Redis failures are logged and never fail the request: the loader is called instead.
+// A load is shared by every caller missing the same key, so it runs detached from the
+// cancellation of the first one; a cancelled caller stops waiting without failing the others.
+func GetOrLoad[T any](ctx context.Context, c *Cache, family Family, key string, load func(context.Context) (T, error)) (T, error) {
+	var zero T
+	fullKey := c.key(family, key)
+
+	raw, err := c.client.Client().Get(ctx, fullKey).Result()
+	switch {
+	case err == nil:
+		if raw == negativeValue && family.NegativeErr != nil {
+			c.metrics.RecordHit(family.Name)
+			return zero, family.NegativeErr
+		}
+
+		var value T
+		if err := json.Unmarshal([]byte(raw), &value); err == nil {
+			c.metrics.RecordHit(family.Name)
+			return value, nil
+		}
+
+		slog.WarnContext(ctx, "Dropping undecodable cache entry", "key", fullKey)
+		c.Delete(ctx, family, key)
+	case !errors.Is(err, redis.Nil):
+		slog.WarnContext(ctx, "Cache get failed", "key", fullKey, "error", err)
+	}
+
+	c.metrics.RecordMiss(family.Name)
+
+	result := c.group.DoChan(fullKey, func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+
+		value, err := load(loadCtx)
+		if err != nil {
+			if family.NegativeErr != nil && family.NegativeTTL > 0 && errors.Is(err, family.NegativeErr) {
+				c.set(loadCtx, fullKey, negativeValue, family.NegativeTTL)
+			}
+			return nil, err
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			slog.WarnContext(loadCtx, "Failed to encode cache entry", "key", fullKey, "error", err)
+			return value, nil
+		}
+		c.set(loadCtx, fullKey, string(data), family.TTL)
+
+		return value, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return zero, res.Err
+		}
+		return res.Val.(T), nil
+	}
+}
+
+// IsNegative reports whether key is cached as known missing
+func (c *Cache) IsNegative(ctx context.Context, family Family, key string) bool {
+	fullKey := c.key(family, key)
+
+	raw, err := c.client.Client().Get(ctx, fullKey).Result()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			slog.WarnContext(ctx, "Cache get failed", "key", fullKey, "error", err)
+		}
+		c.metrics.RecordMiss(family.Name)
+		return false
+	}
+
+	if raw != negativeValue {
+		c.metrics.RecordMiss(family.Name)
+		return false
+	}
+
+	c.metrics.RecordHit(family.Name)
+	return true
+}
+
+// SetNegative caches key as known missing for the family NegativeTTL
+func (c *Cache) SetNegative(ctx context.Context, family Family, key string) {
+	c.set(ctx, c.key(family, key), negativeValue, family.NegativeTTL)
+}
+
+// Delete removes keys of the family before they expire
+func (c *Cache) Delete(ctx context.Context, family Family, keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	fullKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		fullKeys = append(fullKeys, c.key(family, key))
+	}
+
+	deleted, err := c.client.Client().Del(ctx, fullKeys...).Result()
+	if err != nil {
+		slog.WarnContext(ctx, "Cache delete failed", "family", family.Name, "error", err)
+		return
+	}
+	if deleted > 0 {
+		c.metrics.RecordEviction(family.Name, int(deleted))
+	}
+}
+
+func (c *Cache) set(ctx context.Context, key, value string, ttl time.Duration) {
+	if err := c.client.Client().Set(ctx, key, value, ttl).Err(); err != nil {
+		slog.WarnContext(ctx, "Cache set failed", "key", key, "error", err)
+	}
+}
@@ -0,0 +1,195 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+type countingMetrics struct {
+	mu        sync.Mutex
+	hits      map[string]int
+	misses    map[string]int
+	evictions map[string]int
+}
+
+func newCountingMetrics() *countingMetrics {
+	return &countingMetrics{hits: map[string]int{}, misses: map[string]int{}, evictions: map[string]int{}}
+}
+
+func (m *countingMetrics) RecordHit(family string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hits[family]++
+}
+
+func (m *countingMetrics) RecordMiss(family string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.misses[family]++
+}
+
+func (m *countingMetrics) RecordEviction(family string, count int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.evictions[family] += count
+}
+
+func (m *countingMetrics) counts(family string) (hits, misses int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.hits[family], m.misses[family]
+}
+
+var errMissing = errors.New("missing")
+
+func newTestCache(t *testing.T) (*Cache, *countingMetrics) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client, err := NewRedis(context.Background(), server.Addr(), "", 0)
+	if err != nil {
+		t.Fatalf("NewRedis() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	metrics := newCountingMetrics()
+	return NewCache(client, "test", metrics), metrics
+}
+
+func TestGetOrLoadCaches(t *testing.T) {
+	cache, metrics := newTestCache(t)
+	family := Family{Name: "items", TTL: time.Minute}
+
+	var loads atomic.Int32
+	load := func(context.Context) (string, error) {
+		loads.Add(1)
+		return "value", nil
+	}
+
+	for range 3 {
+		got, err := GetOrLoad(context.Background(), cache, family, "key", load)
+		if err != nil {
+			t.Fatalf("GetOrLoad() error = %v", err)
+		}
+		if got != "value" {
+			t.Fatalf("GetOrLoad() = %q, want %q", got, "value")
+		}
+	}
+
+	if got := loads.Load(); got != 1 {
+		t.Fatalf("loader called %d times, want 1", got)
+	}
+	if hits, misses := metrics.counts(family.Name); hits != 2 || misses != 1 {
+		t.Fatalf("got %d hits and %d misses, want 2 and 1", hits, misses)
+	}
+}
+
+func TestGetOrLoadCancelledCallerDoesNotFailWaiters(t *testing.T) {
+	cache, _ := newTestCache(t)
+	family := Family{Name: "items", TTL: time.Minute}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var loads atomic.Int32
+	load := func(ctx context.Context) (string, error) {
+		if loads.Add(1) == 1 {
+			close(started)
+		}
+		select {
+		case <-release:
+			return "value", nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := GetOrLoad(firstCtx, cache, family, "key", load)
+		firstErr <- err
+	}()
+	<-started
+
+	type result struct {
+		value string
+		err   error
+	}
+	second := make(chan result, 1)
+	go func() {
+		value, err := GetOrLoad(context.Background(), cache, family, "key", load)
+		second <- result{value, err}
+	}()
+
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled caller error = %v, want %v", err, context.Canceled)
+	}
+
+	close(release)
+	got := <-second
+	if got.err != nil || got.value != "value" {
+		t.Fatalf("waiter got %q, %v, want %q", got.value, got.err, "value")
+	}
+	if got := loads.Load(); got != 1 {
+		t.Fatalf("loader called %d times, want 1", got)
+	}
+}
+
+func TestNegativeCaching(t *testing.T) {
+	cache, metrics := newTestCache(t)
+	family := Family{Name: "unknown", TTL: time.Minute, NegativeErr: errMissing, NegativeTTL: time.Minute}
+	ctx := context.Background()
+
+	_, err := GetOrLoad(ctx, cache, family, "gone", func(context.Context) (string, error) {
+		return "", errMissing
+	})
+	if !errors.Is(err, errMissing) {
+		t.Fatalf("GetOrLoad() error = %v, want %v", err, errMissing)
+	}
+
+	_, err = GetOrLoad(ctx, cache, family, "gone", func(context.Context) (string, error) {
+		t.Fatal("loader called for a negative entry")
+		return "", nil
+	})
+	if !errors.Is(err, errMissing) {
+		t.Fatalf("GetOrLoad() error = %v, want %v", err, errMissing)
+	}
+
+	if _, err := GetOrLoad(ctx, cache, family, "present", func(context.Context) (string, error) {
+		return "value", nil
+	}); err != nil {
+		t.Fatalf("GetOrLoad() error = %v", err)
+	}
+
+	tests := []struct {
+		key        string
+		want       bool
+		wantHits   int
+		wantMisses int
+	}{
+		{key: "gone", want: true, wantHits: 1},
+		{key: "present", want: false, wantMisses: 1},
+		{key: "absent", want: false, wantMisses: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			hitsBefore, missesBefore := metrics.counts(family.Name)
+			if got := cache.IsNegative(ctx, family, tt.key); got != tt.want {
+				t.Fatalf("IsNegative(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+			hits, misses := metrics.counts(family.Name)
+			if hits-hitsBefore != tt.wantHits || misses-missesBefore != tt.wantMisses {
+				t.Fatalf("IsNegative(%q) recorded %d hits and %d misses, want %d and %d",
+					tt.key, hits-hitsBefore, misses-missesBefore, tt.wantHits, tt.wantMisses)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ func NewRedis(ctx context.Context, addr, password string, db int) (*RedisClient,
 		Password: password,
 		DB:       db,
 	})
+	client.AddHook(newTracingHook(addr, db))
 
 	// Test the connection
 	if err := client.Ping(ctx).Err(); err != nil {
@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go-platform/pkg/tracer"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingHook records every command and pipeline of a traced call as a client span.
+// Only command names are recorded, arguments may hold cached payloads.
+type tracingHook struct {
+	attrs []attribute.KeyValue
+}
+
+func newTracingHook(addr string, db int) tracingHook {
+	return tracingHook{attrs: []attribute.KeyValue{
+		semconv.DBSystemRedis,
+		semconv.DBRedisDBIndex(db),
+		attribute.String("server.address", addr),
+	}}
+}
+
+func (h tracingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h tracingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !traced(ctx) {
+			return next(ctx, cmd)
+		}
+
+		operation := strings.ToUpper(cmd.Name())
+		ctx, span := tracer.StartSpan(ctx, operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(h.attrs...),
+			trace.WithAttributes(semconv.DBOperation(operation)),
+		)
+
+		err := next(ctx, cmd)
+		tracer.EndSpan(span, commandError(err))
+		return err
+	}
+}
+
+func (h tracingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if !traced(ctx) {
+			return next(ctx, cmds)
+		}
+
+		names := make([]string, len(cmds))
+		for i, cmd := range cmds {
+			names[i] = strings.ToUpper(cmd.Name())
+		}
+		ctx, span := tracer.StartSpan(ctx, "PIPELINE",
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(h.attrs...),
+			trace.WithAttributes(
+				semconv.DBOperation("PIPELINE"),
+				semconv.DBStatement(strings.Join(names, " ")),
+				attribute.Int("db.redis.pipeline_length", len(cmds)),
+			),
+		)
+
+		err := next(ctx, cmds)
+		tracer.EndSpan(span, commandError(err))
+		return err
+	}
+}
+
+// traced reports whether ctx is part of a trace, commands of pollers and startup checks are not recorded
+func traced(ctx context.Context) bool {
+	return trace.SpanContextFromContext(ctx).IsValid()
+}
+
+// commandError drops redis.Nil, a missing key is a normal outcome of a lookup
+func commandError(err error) error {
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	return err
+}
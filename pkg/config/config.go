@@ -1,6 +1,8 @@
 package config
 
 import (
+	"time"
+
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
@@ -8,10 +10,15 @@ type Config struct {
 	Server          ServerConfig
 	Database        DatabaseConfig
 	Redis           RedisConfig
+	Cache           CacheConfig
 	NATS            NATSConfig
+	Outbox          OutboxConfig
+	Jobs            JobsConfig
 	Logger          Logger
 	S3              S3
 	DogAPI          DogAPIConfig
+	Breeds          BreedsConfig
+	HTTPClient      HTTPClientConfig
 	MetricsProvider MetricsProviderConfig
 }
 
@@ -28,14 +35,65 @@ type S3 struct {
 	BaseEndpoint       string `env:"S3_STORAGE_ENDPOINT"`        // S3 endpoint (например, http://localhost:9000)
 	BasePublicEndpoint string `env:"S3_STORAGE_ENDPOINT_PUBLIC"` // S3 public endpoint
 	Region             string `env:"S3_STORAGE_REGION"`          // region (например, us-east-1)
+	// Presigned URLs are signed for BasePublicEndpoint so the bucket can stay private
+	PresignGetExpiry time.Duration `env:"S3_PRESIGN_GET_EXPIRY" env-default:"1h"`  // image download URLs
+	PresignPutExpiry time.Duration `env:"S3_PRESIGN_PUT_EXPIRY" env-default:"15m"` // direct upload URLs
+	// Uploads are streamed, larger or unsized ones are split into parts of UploadPartSize (min 5 MiB)
+	UploadPartSize    int64 `env:"S3_UPLOAD_PART_SIZE" env-default:"8388608"`
+	UploadConcurrency int   `env:"S3_UPLOAD_CONCURRENCY" env-default:"2"`
+	MaxObjectSize     int64 `env:"S3_MAX_OBJECT_SIZE" env-default:"20971520"` // 0 disables the cap
 }
 
 type DogAPIConfig struct {
-	BaseURL string `env:"DOG_API_BASE_URL" env-default:"www.example.com"`
+	// Providers lists image providers as name[:weight], comma separated.
+	// Several providers are tried in a weighted random order until one succeeds
+	Providers string `env:"DOG_API_PROVIDERS" env-default:"dogceo"`
+	// BaseURL is the dog.ceo compatible API of the dogceo provider
+	BaseURL string `env:"DOG_API_BASE_URL" env-default:"https://dog.ceo/api"`
+	// TemplateURL is requested by the template provider, {breed} and {count} are substituted
+	TemplateURL string `env:"DOG_API_TEMPLATE_URL"`
+	// TemplateField is the dot separated path to the image URL or URL list in the template provider response
+	TemplateField string `env:"DOG_API_TEMPLATE_FIELD" env-default:"message"`
+	// LocalDir holds one directory of images per breed for the local provider
+	LocalDir string `env:"DOG_API_LOCAL_DIR" env-default:"./data/dogs"`
+	// BatchConcurrency limits parallel downloads and uploads of a batch ingestion
+	BatchConcurrency int `env:"DOG_API_BATCH_CONCURRENCY" env-default:"4"`
+	// BatchMaxSize caps the number of images ingested by a single batch request
+	BatchMaxSize int `env:"DOG_API_BATCH_MAX_SIZE" env-default:"50"`
+}
+
+// BreedsConfig controls the breed catalog sync from the dog.ceo API at DogAPIConfig.BaseURL.
+// With the sync disabled and no stored catalog every breed is accepted, e.g. with only the local provider
+type BreedsConfig struct {
+	SyncEnabled  bool          `env:"BREEDS_SYNC_ENABLED" env-default:"true"`
+	SyncInterval time.Duration `env:"BREEDS_SYNC_INTERVAL" env-default:"24h"`
+}
+
+// HTTPClientConfig tunes the resilience of outbound HTTP calls
+type HTTPClientConfig struct {
+	// Timeout bounds a whole call including retries
+	Timeout time.Duration `env:"HTTP_CLIENT_TIMEOUT" env-default:"10s"`
+	// MaxRetries is the number of retries of an idempotent call after the first attempt
+	MaxRetries int `env:"HTTP_CLIENT_MAX_RETRIES" env-default:"3"`
+	// RetryBaseDelay is doubled on every retry up to RetryMaxDelay, with full jitter
+	RetryBaseDelay time.Duration `env:"HTTP_CLIENT_RETRY_BASE_DELAY" env-default:"100ms"`
+	RetryMaxDelay  time.Duration `env:"HTTP_CLIENT_RETRY_MAX_DELAY" env-default:"2s"`
+	// BreakerFailures consecutive failures of a host open its circuit
+	BreakerFailures int `env:"HTTP_CLIENT_BREAKER_FAILURES" env-default:"5"`
+	// BreakerOpenTimeout is how long an open circuit rejects calls before letting probes through
+	BreakerOpenTimeout time.Duration `env:"HTTP_CLIENT_BREAKER_OPEN_TIMEOUT" env-default:"30s"`
+	// BreakerHalfOpenRequests is the number of concurrent probes of a half-open circuit
+	BreakerHalfOpenRequests int `env:"HTTP_CLIENT_BREAKER_HALF_OPEN_REQUESTS" env-default:"1"`
+	// MaxConcurrent caps the calls in flight, further calls wait for a slot
+	MaxConcurrent int `env:"HTTP_CLIENT_MAX_CONCURRENT" env-default:"16"`
 }
 
 type Logger struct {
 	Level string `env:"LOG_LEVEL" env-default:"info"`
+	// PackageLevels overrides Level per package, e.g. internal/storages=debug,pkg/httpclient=warn
+	PackageLevels string `env:"LOG_PACKAGE_LEVELS"`
+	// SpanEvents copies records logged with a context to the active span as events
+	SpanEvents bool `env:"LOG_SPAN_EVENTS" env-default:"false"`
 }
 
 type DatabaseConfig struct {
@@ -50,8 +108,63 @@ type RedisConfig struct {
 	DB       int    `env:"REDIS_DB" env-default:"0"`
 }
 
+type CacheConfig struct {
+	Enabled     bool          `env:"CACHE_ENABLED" env-default:"true"`
+	Prefix      string        `env:"CACHE_PREFIX" env-default:"go-platform"`
+	DogTTL      time.Duration `env:"CACHE_DOG_TTL" env-default:"1h"`        // by-ID lookups
+	DogsListTTL time.Duration `env:"CACHE_DOGS_LIST_TTL" env-default:"30s"` // per-breed list pages
+	BreedsTTL   time.Duration `env:"CACHE_BREEDS_TTL" env-default:"1h"`     // breed catalog, dropped on every sync
+	S3URLTTL    time.Duration `env:"CACHE_S3_URL_TTL" env-default:"10m"`    // presigned S3 URLs, capped at half of S3_PRESIGN_GET_EXPIRY
+	NegativeTTL time.Duration `env:"CACHE_NEGATIVE_TTL" env-default:"10m"`  // unknown breeds
+}
+
 type NATSConfig struct {
-	URL string `env:"NATS_URL" env-default:"nats://localhost:4222"`
+	URL           string `env:"NATS_URL" env-default:"nats://localhost:4222"`
+	SubjectPrefix string `env:"NATS_SUBJECT_PREFIX" env-default:"go-platform"`
+	JetStream     JetStreamConfig
+}
+
+type JetStreamConfig struct {
+	Enabled bool `env:"NATS_JETSTREAM_ENABLED" env-default:"false"`
+
+	// Stream captures every subject under NATS_SUBJECT_PREFIX
+	StreamName   string        `env:"NATS_STREAM_NAME" env-default:"GO_PLATFORM"`
+	StreamMaxAge time.Duration `env:"NATS_STREAM_MAX_AGE" env-default:"72h"`
+	Replicas     int           `env:"NATS_STREAM_REPLICAS" env-default:"1"`
+
+	// Durable pull consumers
+	AckWait     time.Duration `env:"NATS_CONSUMER_ACK_WAIT" env-default:"30s"`
+	MaxDeliver  int           `env:"NATS_CONSUMER_MAX_DELIVER" env-default:"5"`
+	BackoffBase time.Duration `env:"NATS_CONSUMER_BACKOFF_BASE" env-default:"1s"`
+	BackoffMax  time.Duration `env:"NATS_CONSUMER_BACKOFF_MAX" env-default:"1m"`
+
+	// Messages exceeding MaxDeliver or failing permanently go to {DeadLetterPrefix}.{original subject}
+	DeadLetterPrefix string        `env:"NATS_DEAD_LETTER_PREFIX" env-default:"go-platform-dlq"`
+	DeadLetterMaxAge time.Duration `env:"NATS_DEAD_LETTER_MAX_AGE" env-default:"168h"`
+}
+
+type OutboxConfig struct {
+	PollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL" env-default:"1s"`
+	BatchSize    int           `env:"OUTBOX_BATCH_SIZE" env-default:"100"`
+
+	// A claimed batch is hidden from other relays for ClaimTimeout, unpublished messages are relayed again after it
+	ClaimTimeout time.Duration `env:"OUTBOX_CLAIM_TIMEOUT" env-default:"30s"`
+
+	// A failed message is retried after RetryBackoffBase * 2^(attempts-1) capped at RetryBackoffMax
+	// and parked as dead after MaxAttempts failures
+	MaxAttempts      int           `env:"OUTBOX_MAX_ATTEMPTS" env-default:"20"`
+	RetryBackoffBase time.Duration `env:"OUTBOX_RETRY_BACKOFF_BASE" env-default:"1s"`
+	RetryBackoffMax  time.Duration `env:"OUTBOX_RETRY_BACKOFF_MAX" env-default:"5m"`
+}
+
+type JobsConfig struct {
+	Workers   int           `env:"JOBS_WORKERS" env-default:"4"`
+	QueueSize int           `env:"JOBS_QUEUE_SIZE" env-default:"100"`
+	Timeout   time.Duration `env:"JOBS_TIMEOUT" env-default:"2m"`
+	TTL       time.Duration `env:"JOBS_TTL" env-default:"24h"`
+	// Instance names the queue of this process in Redis, it must be stable across restarts
+	// and unique among instances. The hostname is used when empty.
+	Instance string `env:"JOBS_INSTANCE"`
 }
 
 type MetricsProviderConfig struct {
@@ -59,8 +172,40 @@ type MetricsProviderConfig struct {
 	ServiceVersion string `env:"OTEL_SERVICE_VERSION" env-default:"1.0.0"`
 	Environment    string `env:"ENVIRONMENT" env-default:"development"`
 	OTLPEndpoint   string `env:"OTEL_EXPORTER_OTLP_ENDPOINT" env-default:"localhost:4317"`
+	// OTLPProtocol is grpc or http/protobuf, the endpoint must match the port of the protocol
+	OTLPProtocol   string `env:"OTEL_EXPORTER_OTLP_PROTOCOL" env-default:"grpc"`
 	Insecure       bool   `env:"OTEL_EXPORTER_OTLP_INSECURE" env-default:"true"`
 	PrometheusPort string `env:"PROMETHEUS_PORT" env-default:"9090"`
+	// MetricsExporters lists where the metrics registry goes, comma separated: prometheus serves it
+	// on PrometheusPort, otlp pushes it to the collector every MetricsInterval, none disables both
+	MetricsExporters string        `env:"OTEL_METRICS_EXPORTER" env-default:"prometheus"`
+	MetricsInterval  time.Duration `env:"OTEL_METRICS_INTERVAL" env-default:"15s"`
+	HTTP             HTTPMetricsConfig
+	Tracing          TracingConfig
+}
+
+// HTTPMetricsConfig tunes the histograms of served HTTP requests
+type HTTPMetricsConfig struct {
+	// DurationBuckets and SizeBuckets are comma separated upper bounds in seconds and bytes
+	DurationBuckets string `env:"METRICS_HTTP_DURATION_BUCKETS" env-default:"0.005,0.01,0.025,0.05,0.1,0.25,0.5,1,2.5,5,10"`
+	SizeBuckets     string `env:"METRICS_HTTP_SIZE_BUCKETS" env-default:"100,1000,10000,100000,1000000,10000000"`
+	// Exemplars attach the trace ID of sampled requests to the HTTP metrics,
+	// the registry is then also served in the OpenMetrics format that carries them
+	Exemplars bool `env:"METRICS_EXEMPLARS" env-default:"false"`
+}
+
+// TracingConfig selects the span exporter and the head sampling of traces
+type TracingConfig struct {
+	// Exporter is otlp, stdout or none. With none spans are still created, so logs carry trace IDs
+	Exporter string `env:"OTEL_TRACES_EXPORTER" env-default:"otlp"`
+	// Sampler is always_on, always_off, traceidratio or one of them prefixed with parentbased_,
+	// which follows the decision of the caller when there is one
+	Sampler string `env:"OTEL_TRACES_SAMPLER" env-default:"parentbased_traceidratio"`
+	// SamplerArg is the ratio of traces sampled by the traceidratio samplers
+	SamplerArg float64 `env:"OTEL_TRACES_SAMPLER_ARG" env-default:"1.0"`
+	// SamplingRules override the ratio of new traces per HTTP path or gRPC method prefix,
+	// e.g. /live=0,/go_platform.dogs.DogService/=0.5. The longest matching prefix wins
+	SamplingRules string `env:"TRACING_SAMPLING_RULES" env-default:"/live=0,/swagger/=0,/documentation=0,/router.health.Health/=0"`
 }
 
 func Load() (*Config, error) {
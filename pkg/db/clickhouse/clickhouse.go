@@ -29,7 +29,7 @@ func NewClickHouse(ctx context.Context, dsn string) (*ClickHouseClient, error) {
 		return nil, fmt.Errorf("failed to ping ClickHouse: %w", err)
 	}
 
-	return &ClickHouseClient{conn: conn}, nil
+	return &ClickHouseClient{conn: tracingConn{Conn: conn}}, nil
 }
 
 func (c *ClickHouseClient) Close() {
@@ -0,0 +1,75 @@
+package clickhouse
+
+import (
+	"context"
+
+	"go-platform/pkg/tracer"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+)
+
+// system is the db.system of the ClickHouse spans
+const system = "clickhouse"
+
+// tracingConn records every statement sent through the connection as a client span
+type tracingConn struct {
+	driver.Conn
+}
+
+func (c tracingConn) Select(ctx context.Context, dest any, query string, args ...any) (err error) {
+	ctx, span := tracer.StartDBSpan(ctx, system, query)
+	defer func() { tracer.EndSpan(span, err) }()
+
+	return c.Conn.Select(ctx, dest, query, args...)
+}
+
+func (c tracingConn) Query(ctx context.Context, query string, args ...any) (rows driver.Rows, err error) {
+	ctx, span := tracer.StartDBSpan(ctx, system, query)
+	defer func() { tracer.EndSpan(span, err) }()
+
+	return c.Conn.Query(ctx, query, args...)
+}
+
+func (c tracingConn) QueryRow(ctx context.Context, query string, args ...any) driver.Row {
+	ctx, span := tracer.StartDBSpan(ctx, system, query)
+	row := c.Conn.QueryRow(ctx, query, args...)
+	tracer.EndSpan(span, row.Err())
+
+	return row
+}
+
+func (c tracingConn) Exec(ctx context.Context, query string, args ...any) (err error) {
+	ctx, span := tracer.StartDBSpan(ctx, system, query)
+	defer func() { tracer.EndSpan(span, err) }()
+
+	return c.Conn.Exec(ctx, query, args...)
+}
+
+func (c tracingConn) AsyncInsert(ctx context.Context, query string, wait bool, args ...any) (err error) {
+	ctx, span := tracer.StartDBSpan(ctx, system, query)
+	defer func() { tracer.EndSpan(span, err) }()
+
+	return c.Conn.AsyncInsert(ctx, query, wait, args...)
+}
+
+// PrepareBatch records the batch when it is sent, rows are appended in memory
+func (c tracingConn) PrepareBatch(ctx context.Context, query string, opts ...driver.PrepareBatchOption) (driver.Batch, error) {
+	batch, err := c.Conn.PrepareBatch(ctx, query, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &tracingBatch{Batch: batch, ctx: ctx, query: query}, nil
+}
+
+type tracingBatch struct {
+	driver.Batch
+	ctx   context.Context
+	query string
+}
+
+func (b *tracingBatch) Send() (err error) {
+	_, span := tracer.StartDBSpan(b.ctx, system, b.query)
+	defer func() { tracer.EndSpan(span, err) }()
+
+	return b.Batch.Send()
+}
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/go-sql-driver/mysql"
@@ -19,10 +20,11 @@ func NewMySQL(ctx context.Context, dsn string) (*MySQLClient, error) {
 		return nil, fmt.Errorf("failed to parse DSN: %w", err)
 	}
 
-	db, err := sqlx.ConnectContext(ctx, "mysql", config.FormatDSN())
+	connector, err := mysql.NewConnector(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
+	db := sqlx.NewDb(sql.OpenDB(tracingConnector{Connector: connector}), "mysql")
 
 	if err := db.PingContext(ctx); err != nil {
 		db.Close()
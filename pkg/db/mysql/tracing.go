@@ -0,0 +1,108 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"time"
+
+	"go-platform/pkg/tracer"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// conn is the set of interfaces implemented by the connections of the MySQL driver
+type conn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+	driver.SessionResetter
+	driver.Validator
+	driver.NamedValueChecker
+}
+
+// stmt is the set of interfaces implemented by the prepared statements of the MySQL driver
+type stmt interface {
+	driver.Stmt
+	driver.StmtExecContext
+	driver.StmtQueryContext
+	driver.NamedValueChecker
+}
+
+// tracingConnector opens connections recording every statement as a client span
+type tracingConnector struct {
+	driver.Connector
+}
+
+func (c tracingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	dc, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tc, ok := dc.(conn); ok {
+		return &tracingConn{conn: tc}, nil
+	}
+	return dc, nil
+}
+
+type tracingConn struct {
+	conn
+}
+
+func (c *tracingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	res, err := c.conn.ExecContext(ctx, query, args)
+	recordStatement(ctx, query, start, err)
+	return res, err
+}
+
+func (c *tracingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := c.conn.QueryContext(ctx, query, args)
+	recordStatement(ctx, query, start, err)
+	return rows, err
+}
+
+func (c *tracingConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	ds, err := c.conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if ts, ok := ds.(stmt); ok {
+		return &tracingStmt{stmt: ts, query: query}, nil
+	}
+	return ds, nil
+}
+
+type tracingStmt struct {
+	stmt
+	query string
+}
+
+func (s *tracingStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	res, err := s.stmt.ExecContext(ctx, args)
+	recordStatement(ctx, s.query, start, err)
+	return res, err
+}
+
+func (s *tracingStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := s.stmt.QueryContext(ctx, args)
+	recordStatement(ctx, s.query, start, err)
+	return rows, err
+}
+
+// recordStatement records a finished statement as a span started at start.
+// Statements with arguments are refused with driver.ErrSkip and run again as prepared
+// statements, only the prepared run is recorded.
+func recordStatement(ctx context.Context, query string, start time.Time, err error) {
+	if errors.Is(err, driver.ErrSkip) {
+		return
+	}
+	_, span := tracer.StartDBSpan(ctx, "mysql", query, trace.WithTimestamp(start))
+	tracer.EndSpan(span, err)
+}
@@ -16,6 +16,7 @@ func NewPostgres(ctx context.Context, dsn string) (*PostgresClient, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse DSN: %w", err)
 	}
+	config.ConnConfig.Tracer = queryTracer{}
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -0,0 +1,22 @@
+package postgre
+
+import (
+	"context"
+
+	"go-platform/pkg/tracer"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// queryTracer records every statement sent through the pool as a client span
+type queryTracer struct{}
+
+func (queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx, _ = tracer.StartDBSpan(ctx, "postgresql", data.SQL)
+	return ctx
+}
+
+func (queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	tracer.EndSpan(trace.SpanFromContext(ctx), data.Err)
+}
@@ -0,0 +1,118 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+
+	"go-platform/pkg/utils/errs"
+)
+
+// ErrCircuitOpen is returned without calling the upstream while its circuit is open
+var ErrCircuitOpen = errs.New(errs.UpstreamUnavailable, "circuit breaker is open")
+
+// CircuitState is the state of a host circuit, exported as a gauge value
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitHalfOpen
+	CircuitOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitHalfOpen:
+		return "half_open"
+	default:
+		return "open"
+	}
+}
+
+// breaker is the circuit of a single host. It opens after consecutive failures,
+// rejects calls for the open timeout and then lets a limited number of probes through:
+// a successful probe closes it, a failed one opens it again.
+// Outcomes of calls started before the last state change are ignored.
+type breaker struct {
+	mu         sync.Mutex
+	state      CircuitState
+	generation uint64
+	failures   int
+	openedAt   time.Time
+	probes     int
+
+	maxFailures int
+	openTimeout time.Duration
+	maxProbes   int
+	onChange    func(CircuitState)
+}
+
+// allow reports whether a call may go through and returns the generation to record its outcome with
+func (b *breaker) allow() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen {
+		if time.Since(b.openedAt) < b.openTimeout {
+			return 0, ErrCircuitOpen
+		}
+		b.setState(CircuitHalfOpen)
+	}
+
+	if b.state == CircuitHalfOpen {
+		if b.probes >= b.maxProbes {
+			return 0, ErrCircuitOpen
+		}
+		b.probes++
+	}
+
+	return b.generation, nil
+}
+
+// record reports the outcome of a call let through by allow
+func (b *breaker) record(generation uint64, success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+	if b.state == CircuitHalfOpen {
+		b.probes--
+	}
+
+	if success {
+		b.failures = 0
+		if b.state != CircuitClosed {
+			b.setState(CircuitClosed)
+		}
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.maxFailures {
+		b.openedAt = time.Now()
+		b.setState(CircuitOpen)
+	}
+}
+
+func (b *breaker) setState(state CircuitState) {
+	if b.state == state {
+		return
+	}
+	b.state = state
+	b.generation++
+	b.probes = 0
+	b.onChange(state)
+}
+
+// release frees the probe slot of a call whose outcome says nothing about the host, like a cancelled call
+func (b *breaker) release(generation uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if generation == b.generation && b.state == CircuitHalfOpen {
+		b.probes--
+	}
+}
@@ -0,0 +1,156 @@
+package httpclient
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// breakerStep acts on the breaker and checks its state afterwards.
+// Calls are numbered, allow stores the generation of the call, the other actions report its outcome.
+type breakerStep struct {
+	action    string
+	call      int
+	wantErr   error
+	wantState CircuitState
+}
+
+func TestBreaker(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxFailures int
+		maxProbes   int
+		steps       []breakerStep
+		wantChanges []CircuitState
+	}{
+		{
+			name:        "opens after consecutive failures",
+			maxFailures: 2,
+			maxProbes:   1,
+			steps: []breakerStep{
+				{action: "allow", call: 0, wantState: CircuitClosed},
+				{action: "fail", call: 0, wantState: CircuitClosed},
+				{action: "allow", call: 1, wantState: CircuitClosed},
+				{action: "fail", call: 1, wantState: CircuitOpen},
+				{action: "allow", call: 2, wantErr: ErrCircuitOpen, wantState: CircuitOpen},
+			},
+			wantChanges: []CircuitState{CircuitOpen},
+		},
+		{
+			name:        "success resets failures",
+			maxFailures: 2,
+			maxProbes:   1,
+			steps: []breakerStep{
+				{action: "allow", call: 0, wantState: CircuitClosed},
+				{action: "fail", call: 0, wantState: CircuitClosed},
+				{action: "allow", call: 1, wantState: CircuitClosed},
+				{action: "succeed", call: 1, wantState: CircuitClosed},
+				{action: "allow", call: 2, wantState: CircuitClosed},
+				{action: "fail", call: 2, wantState: CircuitClosed},
+			},
+		},
+		{
+			name:        "successful probe closes",
+			maxFailures: 1,
+			maxProbes:   1,
+			steps: []breakerStep{
+				{action: "allow", call: 0, wantState: CircuitClosed},
+				{action: "fail", call: 0, wantState: CircuitOpen},
+				{action: "expire", wantState: CircuitOpen},
+				{action: "allow", call: 1, wantState: CircuitHalfOpen},
+				{action: "allow", call: 2, wantErr: ErrCircuitOpen, wantState: CircuitHalfOpen},
+				{action: "succeed", call: 1, wantState: CircuitClosed},
+				{action: "allow", call: 3, wantState: CircuitClosed},
+			},
+			wantChanges: []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed},
+		},
+		{
+			name:        "failed probe opens again",
+			maxFailures: 3,
+			maxProbes:   2,
+			steps: []breakerStep{
+				{action: "allow", call: 0, wantState: CircuitClosed},
+				{action: "fail", call: 0, wantState: CircuitClosed},
+				{action: "allow", call: 1, wantState: CircuitClosed},
+				{action: "fail", call: 1, wantState: CircuitClosed},
+				{action: "allow", call: 2, wantState: CircuitClosed},
+				{action: "fail", call: 2, wantState: CircuitOpen},
+				{action: "expire", wantState: CircuitOpen},
+				{action: "allow", call: 3, wantState: CircuitHalfOpen},
+				{action: "allow", call: 4, wantState: CircuitHalfOpen},
+				{action: "fail", call: 3, wantState: CircuitOpen},
+				{action: "succeed", call: 4, wantState: CircuitOpen},
+				{action: "allow", call: 5, wantErr: ErrCircuitOpen, wantState: CircuitOpen},
+			},
+			wantChanges: []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitOpen},
+		},
+		{
+			name:        "outcome of a call from before the last change is ignored",
+			maxFailures: 1,
+			maxProbes:   1,
+			steps: []breakerStep{
+				{action: "allow", call: 0, wantState: CircuitClosed},
+				{action: "allow", call: 1, wantState: CircuitClosed},
+				{action: "fail", call: 1, wantState: CircuitOpen},
+				{action: "expire", wantState: CircuitOpen},
+				{action: "allow", call: 2, wantState: CircuitHalfOpen},
+				{action: "succeed", call: 0, wantState: CircuitHalfOpen},
+				{action: "allow", call: 3, wantErr: ErrCircuitOpen, wantState: CircuitHalfOpen},
+			},
+			wantChanges: []CircuitState{CircuitOpen, CircuitHalfOpen},
+		},
+		{
+			name:        "released probe frees its slot",
+			maxFailures: 1,
+			maxProbes:   1,
+			steps: []breakerStep{
+				{action: "allow", call: 0, wantState: CircuitClosed},
+				{action: "fail", call: 0, wantState: CircuitOpen},
+				{action: "expire", wantState: CircuitOpen},
+				{action: "allow", call: 1, wantState: CircuitHalfOpen},
+				{action: "release", call: 1, wantState: CircuitHalfOpen},
+				{action: "allow", call: 2, wantState: CircuitHalfOpen},
+			},
+			wantChanges: []CircuitState{CircuitOpen, CircuitHalfOpen},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var changes []CircuitState
+			b := &breaker{
+				maxFailures: tt.maxFailures,
+				openTimeout: time.Minute,
+				maxProbes:   tt.maxProbes,
+				onChange:    func(state CircuitState) { changes = append(changes, state) },
+			}
+
+			generations := map[int]uint64{}
+			for i, step := range tt.steps {
+				switch step.action {
+				case "allow":
+					generation, err := b.allow()
+					if !errors.Is(err, step.wantErr) {
+						t.Fatalf("step %d: allow() error = %v, want %v", i, err, step.wantErr)
+					}
+					generations[step.call] = generation
+				case "succeed", "fail":
+					b.record(generations[step.call], step.action == "succeed")
+				case "release":
+					b.release(generations[step.call])
+				case "expire":
+					b.openedAt = b.openedAt.Add(-b.openTimeout)
+				}
+
+				if b.state != step.wantState {
+					t.Fatalf("step %d: %s call %d left the circuit %v, want %v", i, step.action, step.call, b.state, step.wantState)
+				}
+			}
+
+			if !slices.Equal(changes, tt.wantChanges) {
+				t.Fatalf("state changes = %v, want %v", changes, tt.wantChanges)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+)
+
+// cancellation reasons, used as status labels and in logs
+const (
+	ReasonCanceled         = "canceled"
+	ReasonDeadlineExceeded = "deadline_exceeded"
+)
+
+// CancellationReason tells why a call failed because its context ended: the caller went away
+// or shut down, or its deadline passed. It is empty when err is not caused by ctx.
+func CancellationReason(ctx context.Context, err error) string {
+	if err == nil || ctx.Err() == nil {
+		return ""
+	}
+
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return ReasonDeadlineExceeded
+	default:
+		return ReasonCanceled
+	}
+}
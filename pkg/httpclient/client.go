@@ -0,0 +1,270 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go-platform/pkg/config"
+	"go-platform/pkg/requestid"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"resty.dev/v3"
+)
+
+const tracerName = "go-platform/pkg/httpclient"
+
+// status labels of calls that got no response, besides the cancellation reasons
+const (
+	statusError       = "error"
+	statusCircuitOpen = "circuit_open"
+)
+
+// Metrics records outbound calls per upstream host
+type Metrics interface {
+	RecordRequest(host, method, status string, duration time.Duration)
+	RecordRetry(host string)
+	RecordCircuitState(host string, state int)
+}
+
+// Client is an outbound HTTP client shared by the upstream clients.
+// Idempotent calls are retried with exponential backoff and full jitter, every host has
+// its own circuit breaker and a bulkhead caps the calls in flight across all hosts.
+// Every attempt is recorded as a client span with the trace context and request ID propagated in its headers.
+type Client struct {
+	cfg       config.HTTPClientConfig
+	base      http.RoundTripper
+	metrics   Metrics
+	tracer    trace.Tracer
+	bulkhead  chan struct{}
+	breakers  sync.Map // host -> *breaker
+	transport *transport
+}
+
+// NewClient creates an outbound HTTP client
+func NewClient(cfg config.HTTPClientConfig, metrics Metrics) *Client {
+	c := &Client{
+		cfg:      cfg,
+		base:     http.DefaultTransport,
+		metrics:  metrics,
+		tracer:   otel.Tracer(tracerName),
+		bulkhead: make(chan struct{}, max(cfg.MaxConcurrent, 1)),
+	}
+	c.transport = &transport{client: c}
+
+	return c
+}
+
+// HTTP returns a standard library client built on the resilience layer
+func (c *Client) HTTP() *http.Client {
+	return &http.Client{Transport: c.transport, Timeout: c.cfg.Timeout}
+}
+
+// Resty returns a new resty client built on the resilience layer. Retries are left to the layer.
+func (c *Client) Resty() *resty.Client {
+	return resty.New().
+		SetTransport(c.transport).
+		SetTimeout(c.cfg.Timeout)
+}
+
+// transport is the http.RoundTripper of the client
+type transport struct {
+	client *Client
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.client.roundTrip(req)
+}
+
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	retryable := isIdempotent(req.Method) && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+
+	for attempt := 0; ; attempt++ {
+		res, err := c.attempt(req, host, attempt)
+
+		if !retryable || attempt >= c.cfg.MaxRetries || !shouldRetry(req.Context(), res, err) {
+			return res, err
+		}
+
+		delay := c.backoff(attempt, res)
+		if res != nil {
+			// the connection is reused only if the body is read to the end
+			io.Copy(io.Discard, io.LimitReader(res.Body, 4096))
+			res.Body.Close()
+		}
+		c.metrics.RecordRetry(host)
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// attempt sends the request once through the bulkhead and the host circuit
+func (c *Client) attempt(req *http.Request, host string, attempt int) (*http.Response, error) {
+	ctx, span := c.tracer.Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Hostname()),
+			attribute.String("url.full", req.URL.Redacted()),
+			attribute.Int("http.request.resend_count", attempt),
+		),
+	)
+	defer span.End()
+
+	start := time.Now()
+
+	// the slot is held until the response body is closed, so streamed downloads count as in flight
+	var releaseSlot sync.Once
+	release := func() { releaseSlot.Do(func() { <-c.bulkhead }) }
+	select {
+	case c.bulkhead <- struct{}{}:
+	case <-ctx.Done():
+		reason := CancellationReason(ctx, ctx.Err())
+		c.metrics.RecordRequest(host, req.Method, reason, time.Since(start))
+		span.SetAttributes(attribute.String("cancellation.reason", reason))
+		span.SetStatus(codes.Error, "bulkhead wait "+reason)
+		return nil, ctx.Err()
+	}
+
+	b := c.breaker(host)
+	generation, err := b.allow()
+	if err != nil {
+		release()
+		c.metrics.RecordRequest(host, req.Method, statusCircuitOpen, time.Since(start))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("%s: %w", host, err)
+	}
+
+	out := req.Clone(ctx)
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			b.release(generation)
+			release()
+			return nil, fmt.Errorf("failed to rewind request body: %w", err)
+		}
+		out.Body = body
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(out.Header))
+	if id := requestid.FromContext(ctx); id != "" && out.Header.Get(requestid.Header) == "" {
+		out.Header.Set(requestid.Header, id)
+	}
+
+	res, err := c.base.RoundTrip(out)
+	if err != nil {
+		release()
+		status := statusError
+		if reason := CancellationReason(ctx, err); reason != "" {
+			// the caller gave up, this says nothing about the health of the host
+			b.release(generation)
+			status = reason
+			span.SetAttributes(attribute.String("cancellation.reason", reason))
+		} else {
+			b.record(generation, false)
+		}
+		c.metrics.RecordRequest(host, req.Method, status, time.Since(start))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	b.record(generation, res.StatusCode < http.StatusInternalServerError)
+	c.metrics.RecordRequest(host, req.Method, strconv.Itoa(res.StatusCode), time.Since(start))
+	span.SetAttributes(attribute.Int("http.response.status_code", res.StatusCode))
+	if res.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, res.Status)
+	}
+	res.Body = &releasingBody{ReadCloser: res.Body, release: release}
+
+	return res, nil
+}
+
+// releasingBody frees the bulkhead slot of its call when closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
+
+// breaker returns the circuit of the host
+func (c *Client) breaker(host string) *breaker {
+	if b, ok := c.breakers.Load(host); ok {
+		return b.(*breaker)
+	}
+
+	b, _ := c.breakers.LoadOrStore(host, &breaker{
+		maxFailures: max(c.cfg.BreakerFailures, 1),
+		openTimeout: c.cfg.BreakerOpenTimeout,
+		maxProbes:   max(c.cfg.BreakerHalfOpenRequests, 1),
+		onChange: func(state CircuitState) {
+			c.metrics.RecordCircuitState(host, int(state))
+		},
+	})
+	return b.(*breaker)
+}
+
+// backoff returns the delay before the next attempt: a Retry-After of the upstream when it sent one,
+// otherwise a random delay up to the exponentially growing cap
+func (c *Client) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, c.cfg.RetryMaxDelay)
+		}
+	}
+
+	ceiling := c.cfg.RetryMaxDelay
+	if attempt < 30 {
+		ceiling = min(c.cfg.RetryBaseDelay<<attempt, c.cfg.RetryMaxDelay)
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return rand.N(ceiling)
+}
+
+// shouldRetry reports whether a failed attempt may succeed when repeated
+func shouldRetry(ctx context.Context, res *http.Response, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,121 @@
+package imaging
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+
+	// registered decoders are the only formats accepted
+	_ "image/gif"
+	_ "image/png"
+)
+
+// ErrUnsupportedFormat is returned when the bytes are not a JPEG, PNG or GIF image
+var ErrUnsupportedFormat = errors.New("unsupported image format")
+
+var mimeTypes = map[string]string{
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+	"gif":  "image/gif",
+}
+
+// Info describes an image without decoding its pixels
+type Info struct {
+	Format   string
+	MIMEType string
+	Width    int
+	Height   int
+}
+
+// Probe reads the image header and returns its format and dimensions
+func Probe(r io.Reader) (*Info, error) {
+	cfg, format, err := image.DecodeConfig(r)
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, ErrUnsupportedFormat
+		}
+		return nil, fmt.Errorf("failed to read image header: %w", err)
+	}
+
+	mimeType, ok := mimeTypes[format]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	return &Info{Format: format, MIMEType: mimeType, Width: cfg.Width, Height: cfg.Height}, nil
+}
+
+// Decode decodes the image, animated GIFs are decoded to their first frame
+func Decode(r io.Reader) (image.Image, error) {
+	img, format, err := image.Decode(r)
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, ErrUnsupportedFormat
+		}
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	if _, ok := mimeTypes[format]; !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	return img, nil
+}
+
+// Fit scales img down to fit within size x size keeping the aspect ratio.
+// Every destination pixel is the average of the source pixels it covers, so no detail is skipped.
+// Transparent pixels are flattened onto white. Images already small enough are only flattened.
+func Fit(img image.Image, size int) *image.RGBA {
+	src := img.Bounds()
+	sw, sh := src.Dx(), src.Dy()
+
+	dw, dh := sw, sh
+	if sw > size || sh > size {
+		if sw >= sh {
+			dw, dh = size, max(sh*size/sw, 1)
+		} else {
+			dw, dh = max(sw*size/sh, 1), size
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for dy := range dh {
+		y0 := src.Min.Y + dy*sh/dh
+		y1 := max(src.Min.Y+(dy+1)*sh/dh, y0+1)
+		for dx := range dw {
+			x0 := src.Min.X + dx*sw/dw
+			x1 := max(src.Min.X+(dx+1)*sw/dw, x0+1)
+
+			var r, g, b, a, n uint64
+			for y := y0; y < y1; y++ {
+				for x := x0; x < x1; x++ {
+					cr, cg, cb, ca := img.At(x, y).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			r, g, b, a = r/n, g/n, b/n, a/n
+
+			// colors are alpha-premultiplied, adding the uncovered part of white flattens them
+			white := 0xffff - a
+			dst.SetRGBA(dx, dy, color.RGBA{
+				R: uint8((r + white) >> 8),
+				G: uint8((g + white) >> 8),
+				B: uint8((b + white) >> 8),
+				A: 0xff,
+			})
+		}
+	}
+
+	return dst
+}
+
+// EncodeJPEG writes img as a JPEG of the given quality (1-100)
+func EncodeJPEG(w io.Writer, img image.Image, quality int) error {
+	if err := jpeg.Encode(w, img, &jpeg.Options{Quality: quality}); err != nil {
+		return fmt.Errorf("failed to encode JPEG: %w", err)
+	}
+	return nil
+}
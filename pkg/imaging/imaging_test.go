@@ -0,0 +1,83 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func filled(w, h int, c color.Color) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestFitSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		size          int
+		wantW, wantH  int
+	}{
+		{name: "landscape", width: 400, height: 200, size: 100, wantW: 100, wantH: 50},
+		{name: "portrait", width: 200, height: 400, size: 100, wantW: 50, wantH: 100},
+		{name: "square", width: 300, height: 300, size: 100, wantW: 100, wantH: 100},
+		{name: "already small", width: 80, height: 40, size: 100, wantW: 80, wantH: 40},
+		{name: "exact size", width: 100, height: 60, size: 100, wantW: 100, wantH: 60},
+		{name: "thin strip keeps a row", width: 1000, height: 2, size: 100, wantW: 100, wantH: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Fit(filled(tt.width, tt.height, color.Black), tt.size).Bounds()
+			if got.Dx() != tt.wantW || got.Dy() != tt.wantH {
+				t.Fatalf("Fit() = %dx%d, want %dx%d", got.Dx(), got.Dy(), tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestFitColors(t *testing.T) {
+	stripes := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	for y := range 4 {
+		for x := range 4 {
+			if x%2 == 0 {
+				stripes.Set(x, y, color.Black)
+			} else {
+				stripes.Set(x, y, color.White)
+			}
+		}
+	}
+	offset := filled(20, 10, color.NRGBA{R: 200, G: 100, B: 50, A: 0xff}).SubImage(image.Rect(10, 5, 20, 10))
+
+	tests := []struct {
+		name string
+		img  image.Image
+		size int
+		want color.RGBA
+	}{
+		{name: "opaque", img: filled(10, 10, color.NRGBA{R: 200, G: 100, B: 50, A: 0xff}), size: 5, want: color.RGBA{R: 200, G: 100, B: 50, A: 0xff}},
+		{name: "transparent flattened onto white", img: filled(10, 10, color.NRGBA{}), size: 5, want: color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}},
+		{name: "half transparent black", img: filled(10, 10, color.NRGBA{A: 0x80}), size: 5, want: color.RGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0xff}},
+		{name: "pixels averaged", img: stripes, size: 2, want: color.RGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0xff}},
+		{name: "bounds not at origin", img: offset, size: 5, want: color.RGBA{R: 200, G: 100, B: 50, A: 0xff}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Fit(tt.img, tt.size)
+			bounds := got.Bounds()
+			for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+				for x := bounds.Min.X; x < bounds.Max.X; x++ {
+					if c := got.RGBAAt(x, y); c != tt.want {
+						t.Fatalf("Fit() pixel (%d, %d) = %v, want %v", x, y, c, tt.want)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+
+	"go-platform/pkg/requestid"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type attrsKey struct{}
+
+// ContextWithAttrs returns a copy of ctx whose records logged with it carry attrs
+// after the attributes added by its parents
+func ContextWithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	parent, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	merged := make([]slog.Attr, 0, len(parent)+len(attrs))
+	merged = append(append(merged, parent...), attrs...)
+	return context.WithValue(ctx, attrsKey{}, merged)
+}
+
+// packageLevel is the minimum level of the packages under prefix
+type packageLevel struct {
+	prefix string
+	level  slog.Level
+}
+
+// handler is a slog.Handler that enriches every record with the context it is logged with:
+// trace_id and span_id of the active span, the request ID and attributes added by ContextWithAttrs.
+// The level can be overridden per package and records can be copied to the active span as events.
+// Records logged without a context, e.g. slog.Info, are only filtered by level.
+type handler struct {
+	next       slog.Handler
+	level      slog.Level
+	packages   []packageLevel // longest prefix first
+	minLevel   slog.Level     // lowest level of any package, records below it are dropped early
+	spanEvents bool
+	levels     *sync.Map // pc -> slog.Level, shared by the handlers derived with WithAttrs and WithGroup
+}
+
+// newHandler wraps next, which must accept every record from the lowest configured level
+func newHandler(next slog.Handler, level slog.Level, packages []packageLevel, spanEvents bool) *handler {
+	return &handler{
+		next:       next,
+		level:      level,
+		packages:   packages,
+		minLevel:   lowestLevel(level, packages),
+		spanEvents: spanEvents,
+		levels:     &sync.Map{},
+	}
+}
+
+// lowestLevel returns the lowest of level and the package levels
+func lowestLevel(level slog.Level, packages []packageLevel) slog.Level {
+	for _, p := range packages {
+		level = min(level, p.level)
+	}
+	return level
+}
+
+// Enabled is asked before the caller is known, so it only drops records no package would log
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.minLevel && h.next.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < h.levelFor(r.PC) {
+		return nil
+	}
+	if h.spanEvents {
+		addSpanEvent(ctx, r)
+	}
+
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if attrs, ok := ctx.Value(attrsKey{}).([]slog.Attr); ok {
+		r.AddAttrs(attrs...)
+	}
+
+	return h.next.Handle(ctx, r)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	derived := *h
+	derived.next = h.next.WithAttrs(attrs)
+	return &derived
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	derived := *h
+	derived.next = h.next.WithGroup(name)
+	return &derived
+}
+
+// levelFor returns the level of the package the record was logged from
+func (h *handler) levelFor(pc uintptr) slog.Level {
+	if len(h.packages) == 0 || pc == 0 {
+		return h.level
+	}
+	if level, ok := h.levels.Load(pc); ok {
+		return level.(slog.Level)
+	}
+
+	level := h.level
+	pkg := packageOf(pc)
+	for _, p := range h.packages {
+		if pkg == p.prefix || strings.HasPrefix(pkg, p.prefix+"/") {
+			level = p.level
+			break
+		}
+	}
+
+	h.levels.Store(pc, level)
+	return level
+}
+
+// packageOf returns the import path of the package of the function at pc
+func packageOf(pc uintptr) string {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	name := frame.Function // e.g. go-platform/internal/storages/postgresql.(*PostgresRepository).InsertDog
+
+	slash := strings.LastIndex(name, "/")
+	if dot := strings.Index(name[slash+1:], "."); dot >= 0 {
+		return name[:slash+1+dot]
+	}
+	return name
+}
+
+// addSpanEvent records r as an event of the active span, so the log line shows up in the trace
+func addSpanEvent(ctx context.Context, r slog.Record) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	attrs := make([]attribute.KeyValue, 0, r.NumAttrs()+1)
+	attrs = append(attrs, attribute.String("log.severity", r.Level.String()))
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, attribute.String(a.Key, a.Value.String()))
+		return true
+	})
+
+	span.AddEvent(r.Message, trace.WithTimestamp(r.Time), trace.WithAttributes(attrs...))
+}
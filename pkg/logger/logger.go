@@ -4,6 +4,8 @@ import (
 	"go-platform/pkg/config"
 	"log/slog"
 	"os"
+	"runtime/debug"
+	"sort"
 	"strings"
 	"sync"
 )
@@ -12,26 +14,87 @@ var loggerOnce = sync.Once{}
 
 func InitLogger(cfg config.Logger) {
 	loggerOnce.Do(func() {
-		logger := slog.New(
-			slog.NewJSONHandler(os.Stdout,
-				&slog.HandlerOptions{
-					Level: func() slog.Leveler {
-						switch strings.ToLower(cfg.Level) {
-						case "debug":
-							return slog.LevelDebug
-						case "info":
-							return slog.LevelInfo
-						case "warn":
-							return slog.LevelWarn
-						case "error":
-							return slog.LevelError
-						default:
-							return slog.LevelInfo
-						}
-					}(),
-				},
-			),
-		)
-		slog.SetDefault(logger)
+		level, ok := parseLevel(cfg.Level)
+		if !ok {
+			level = slog.LevelInfo
+		}
+		packages, invalid := parsePackageLevels(cfg.PackageLevels, modulePath())
+
+		// the JSON handler accepts every record the package levels may let through
+		json := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: lowestLevel(level, packages)})
+		slog.SetDefault(slog.New(newHandler(json, level, packages, cfg.SpanEvents)))
+
+		for _, entry := range invalid {
+			slog.Warn("Ignoring invalid package log level", "entry", entry)
+		}
+	})
+}
+
+func parseLevel(level string) (slog.Level, bool) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "warn":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	default:
+		return slog.LevelInfo, false
+	}
+}
+
+// modulePath returns the main module path, empty when the binary carries no build info
+func modulePath() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Path
+	}
+	return ""
+}
+
+// parsePackageLevels parses "package=level,..." overrides, packages are import paths with or
+// without the module path, e.g. internal/storages=debug. Paths whose first element has a dot,
+// like github.com/nats-io/nats.go, are dependencies and kept as is. Invalid entries are returned apart.
+func parsePackageLevels(spec, module string) ([]packageLevel, []string) {
+	if module != "" {
+		module += "/"
+	}
+
+	var (
+		packages []packageLevel
+		invalid  []string
+	)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		pkg, name, found := strings.Cut(entry, "=")
+		pkg = strings.Trim(strings.TrimSpace(pkg), "/")
+		level, ok := parseLevel(name)
+		if !found || pkg == "" || !ok {
+			invalid = append(invalid, entry)
+			continue
+		}
+		if module != "" && !strings.HasPrefix(pkg+"/", module) && !isDependency(pkg) {
+			pkg = module + pkg
+		}
+
+		packages = append(packages, packageLevel{prefix: pkg, level: level})
+	}
+
+	// the most specific override wins
+	sort.SliceStable(packages, func(i, j int) bool {
+		return len(packages[i].prefix) > len(packages[j].prefix)
 	})
+
+	return packages, invalid
+}
+
+// isDependency reports whether the import path is outside the main module, their first element is a domain
+func isDependency(pkg string) bool {
+	first, _, _ := strings.Cut(pkg, "/")
+	return strings.Contains(first, ".")
 }
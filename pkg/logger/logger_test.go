@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"log/slog"
+	"slices"
+	"testing"
+)
+
+func TestParsePackageLevels(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        string
+		module      string
+		want        []packageLevel
+		wantInvalid []string
+	}{
+		{
+			name:   "module relative path",
+			spec:   "internal/storages=debug",
+			module: "go-platform",
+			want:   []packageLevel{{prefix: "go-platform/internal/storages", level: slog.LevelDebug}},
+		},
+		{
+			name:   "full module path",
+			spec:   "go-platform/pkg/cache=warn",
+			module: "go-platform",
+			want:   []packageLevel{{prefix: "go-platform/pkg/cache", level: slog.LevelWarn}},
+		},
+		{
+			name:   "dependency",
+			spec:   "github.com/nats-io/nats.go=warn",
+			module: "go-platform",
+			want:   []packageLevel{{prefix: "github.com/nats-io/nats.go", level: slog.LevelWarn}},
+		},
+		{
+			name:   "dependency of a module hosted on a domain",
+			spec:   "internal/jobs=error, golang.org/x/net=debug",
+			module: "github.com/acme/platform",
+			want: []packageLevel{
+				{prefix: "github.com/acme/platform/internal/jobs", level: slog.LevelError},
+				{prefix: "golang.org/x/net", level: slog.LevelDebug},
+			},
+		},
+		{
+			name:   "without build info",
+			spec:   "internal/storages=debug",
+			module: "",
+			want:   []packageLevel{{prefix: "internal/storages", level: slog.LevelDebug}},
+		},
+		{
+			name:   "most specific first",
+			spec:   "/internal/=info,internal/storages/postgresql=debug",
+			module: "go-platform",
+			want: []packageLevel{
+				{prefix: "go-platform/internal/storages/postgresql", level: slog.LevelDebug},
+				{prefix: "go-platform/internal", level: slog.LevelInfo},
+			},
+		},
+		{
+			name:        "invalid entries",
+			spec:        "internal=loud,=debug,internal,,pkg=WARN",
+			module:      "go-platform",
+			want:        []packageLevel{{prefix: "go-platform/pkg", level: slog.LevelWarn}},
+			wantInvalid: []string{"internal=loud", "=debug", "internal"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, invalid := parsePackageLevels(tt.spec, tt.module)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("parsePackageLevels() = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(invalid, tt.wantInvalid) {
+				t.Fatalf("parsePackageLevels() invalid = %q, want %q", invalid, tt.wantInvalid)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// CacheMetrics holds cache-related metrics
+type CacheMetrics struct {
+	CacheHits      *prometheus.CounterVec
+	CacheMisses    *prometheus.CounterVec
+	CacheEvictions *prometheus.CounterVec
+}
+
+// NewCacheMetrics creates a new cache metrics instance
+func NewCacheMetrics(registry *prometheus.Registry) *CacheMetrics {
+	return &CacheMetrics{
+		CacheHits: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "cache_hits_total",
+				Help: "Total number of cache hits",
+			},
+			[]string{"family"},
+		),
+
+		CacheMisses: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "cache_misses_total",
+				Help: "Total number of cache misses",
+			},
+			[]string{"family"},
+		),
+
+		CacheEvictions: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "cache_evictions_total",
+				Help: "Total number of cache entries removed before expiration",
+			},
+			[]string{"family"},
+		),
+	}
+}
+
+// RecordHit records a cache hit for the key family
+func (c *CacheMetrics) RecordHit(family string) {
+	c.CacheHits.WithLabelValues(family).Inc()
+}
+
+// RecordMiss records a cache miss for the key family
+func (c *CacheMetrics) RecordMiss(family string) {
+	c.CacheMisses.WithLabelValues(family).Inc()
+}
+
+// RecordEviction records removed cache entries for the key family
+func (c *CacheMetrics) RecordEviction(family string, count int) {
+	c.CacheEvictions.WithLabelValues(family).Add(float64(count))
+}
@@ -0,0 +1,168 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC call types used as the grpc_type label
+const (
+	grpcUnary        = "unary"
+	grpcClientStream = "client_stream"
+	grpcServerStream = "server_stream"
+	grpcBidiStream   = "bidi_stream"
+)
+
+// GRPCMetrics holds gRPC server metrics per service and method
+type GRPCMetrics struct {
+	StartedTotal       *prometheus.CounterVec
+	HandledTotal       *prometheus.CounterVec
+	HandlingDuration   *prometheus.HistogramVec
+	InFlight           *prometheus.GaugeVec
+	StreamMsgsReceived *prometheus.CounterVec
+	StreamMsgsSent     *prometheus.CounterVec
+}
+
+// NewGRPCMetrics creates a new gRPC server metrics instance
+func NewGRPCMetrics(registry *prometheus.Registry) *GRPCMetrics {
+	return &GRPCMetrics{
+		StartedTotal: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "grpc_server_started_total",
+				Help: "Total number of gRPC calls started on the server",
+			},
+			[]string{"grpc_type", "grpc_service", "grpc_method"},
+		),
+
+		HandledTotal: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "grpc_server_handled_total",
+				Help: "Total number of gRPC calls completed on the server, regardless of success or failure",
+			},
+			[]string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"},
+		),
+
+		HandlingDuration: promauto.With(registry).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "grpc_server_handling_seconds",
+				Help:    "gRPC call duration until the handler returns in seconds",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"},
+		),
+
+		InFlight: promauto.With(registry).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "grpc_server_in_flight",
+				Help: "Current number of gRPC calls being handled",
+			},
+			[]string{"grpc_type", "grpc_service", "grpc_method"},
+		),
+
+		StreamMsgsReceived: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "grpc_server_stream_msg_received_total",
+				Help: "Total number of messages received on gRPC streams",
+			},
+			[]string{"grpc_type", "grpc_service", "grpc_method"},
+		),
+
+		StreamMsgsSent: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "grpc_server_stream_msg_sent_total",
+				Help: "Total number of messages sent on gRPC streams",
+			},
+			[]string{"grpc_type", "grpc_service", "grpc_method"},
+		),
+	}
+}
+
+// UnaryServerInterceptor records metrics for unary calls.
+// It should come first in the chain so calls rejected by later interceptors are counted.
+func (g *GRPCMetrics) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	done := g.start(grpcUnary, info.FullMethod)
+	resp, err := handler(ctx, req)
+	done(err)
+	return resp, err
+}
+
+// StreamServerInterceptor records metrics for streaming calls and counts their messages
+func (g *GRPCMetrics) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	grpcType := streamType(info)
+	service, method := splitMethod(info.FullMethod)
+
+	done := g.start(grpcType, info.FullMethod)
+	err := handler(srv, &monitoredStream{
+		ServerStream: ss,
+		received:     g.StreamMsgsReceived.WithLabelValues(grpcType, service, method),
+		sent:         g.StreamMsgsSent.WithLabelValues(grpcType, service, method),
+	})
+	done(err)
+	return err
+}
+
+// start records the start of a call, the returned function records its completion
+func (g *GRPCMetrics) start(grpcType, fullMethod string) func(error) {
+	start := time.Now()
+	service, method := splitMethod(fullMethod)
+
+	g.StartedTotal.WithLabelValues(grpcType, service, method).Inc()
+	inFlight := g.InFlight.WithLabelValues(grpcType, service, method)
+	inFlight.Inc()
+
+	return func(err error) {
+		inFlight.Dec()
+		code := status.Code(err).String()
+		g.HandledTotal.WithLabelValues(grpcType, service, method, code).Inc()
+		g.HandlingDuration.WithLabelValues(grpcType, service, method, code).Observe(time.Since(start).Seconds())
+	}
+}
+
+// splitMethod splits /package.Service/Method into the service and method names
+func splitMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "unknown", "unknown"
+	}
+	return service, method
+}
+
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return grpcBidiStream
+	case info.IsClientStream:
+		return grpcClientStream
+	default:
+		return grpcServerStream
+	}
+}
+
+// monitoredStream counts the messages successfully sent and received on a server stream
+type monitoredStream struct {
+	grpc.ServerStream
+	received prometheus.Counter
+	sent     prometheus.Counter
+}
+
+func (s *monitoredStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent.Inc()
+	}
+	return err
+}
+
+func (s *monitoredStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received.Inc()
+	}
+	return err
+}
@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeStream delivers one message to the handler, then io.EOF
+type fakeStream struct {
+	grpc.ServerStream
+	pending int
+}
+
+func (s *fakeStream) Context() context.Context { return context.Background() }
+
+func (s *fakeStream) SendMsg(interface{}) error { return nil }
+
+func (s *fakeStream) RecvMsg(interface{}) error {
+	if s.pending == 0 {
+		return io.EOF
+	}
+	s.pending--
+	return nil
+}
+
+func TestUnaryServerInterceptorRecordsCodes(t *testing.T) {
+	g := NewGRPCMetrics(prometheus.NewRegistry())
+	info := &grpc.UnaryServerInfo{FullMethod: "/go_platform.dogs.DogService/GetDog"}
+
+	ok := func(context.Context, interface{}) (interface{}, error) { return "dog", nil }
+	notFound := func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "dog not found")
+	}
+	for _, handler := range []grpc.UnaryHandler{ok, ok, notFound} {
+		g.UnaryServerInterceptor(context.Background(), nil, info, handler)
+	}
+
+	labels := []string{grpcUnary, "go_platform.dogs.DogService", "GetDog"}
+	if got := testutil.ToFloat64(g.StartedTotal.WithLabelValues(labels...)); got != 3 {
+		t.Fatalf("grpc_server_started_total = %v, want 3", got)
+	}
+	if got := testutil.ToFloat64(g.HandledTotal.WithLabelValues(append(labels, "OK")...)); got != 2 {
+		t.Fatalf("grpc_server_handled_total{grpc_code=OK} = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(g.HandledTotal.WithLabelValues(append(labels, "NotFound")...)); got != 1 {
+		t.Fatalf("grpc_server_handled_total{grpc_code=NotFound} = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(g.InFlight.WithLabelValues(labels...)); got != 0 {
+		t.Fatalf("grpc_server_in_flight = %v, want 0", got)
+	}
+	if got := testutil.CollectAndCount(g.HandlingDuration); got != 2 {
+		t.Fatalf("grpc_server_handling_seconds series = %d, want 2", got)
+	}
+}
+
+func TestStreamServerInterceptorCountsMessages(t *testing.T) {
+	g := NewGRPCMetrics(prometheus.NewRegistry())
+	info := &grpc.StreamServerInfo{FullMethod: "/go_platform.dogs.DogService/GetRandomDogImages", IsServerStream: true}
+
+	// the handler reads the request, then sends two images before the client cancels
+	handler := func(_ interface{}, ss grpc.ServerStream) error {
+		for ss.RecvMsg(nil) == nil {
+		}
+		ss.SendMsg("first")
+		ss.SendMsg("second")
+		return status.Error(codes.Canceled, "client went away")
+	}
+	g.StreamServerInterceptor(nil, &fakeStream{pending: 1}, info, handler)
+
+	labels := []string{grpcServerStream, "go_platform.dogs.DogService", "GetRandomDogImages"}
+	if got := testutil.ToFloat64(g.StreamMsgsReceived.WithLabelValues(labels...)); got != 1 {
+		t.Fatalf("grpc_server_stream_msg_received_total = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(g.StreamMsgsSent.WithLabelValues(labels...)); got != 2 {
+		t.Fatalf("grpc_server_stream_msg_sent_total = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(g.HandledTotal.WithLabelValues(append(labels, "Canceled")...)); got != 1 {
+		t.Fatalf("grpc_server_handled_total{grpc_code=Canceled} = %v, want 1", got)
+	}
+}
+
+func TestSplitMethod(t *testing.T) {
+	tests := []struct {
+		fullMethod  string
+		wantService string
+		wantMethod  string
+	}{
+		{fullMethod: "/go_platform.dogs.DogService/GetDog", wantService: "go_platform.dogs.DogService", wantMethod: "GetDog"},
+		{fullMethod: "malformed", wantService: "unknown", wantMethod: "unknown"},
+	}
+
+	for _, tt := range tests {
+		service, method := splitMethod(tt.fullMethod)
+		if service != tt.wantService || method != tt.wantMethod {
+			t.Fatalf("splitMethod(%q) = %q, %q, want %q, %q", tt.fullMethod, service, method, tt.wantService, tt.wantMethod)
+		}
+	}
+}
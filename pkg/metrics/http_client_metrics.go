@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// HTTPClientMetrics holds outbound HTTP call metrics per upstream host
+type HTTPClientMetrics struct {
+	RequestsTotal   *prometheus.CounterVec
+	RequestDuration *prometheus.HistogramVec
+	RetriesTotal    *prometheus.CounterVec
+	CircuitState    *prometheus.GaugeVec
+}
+
+// NewHTTPClientMetrics creates a new outbound HTTP metrics instance
+func NewHTTPClientMetrics(registry *prometheus.Registry) *HTTPClientMetrics {
+	return &HTTPClientMetrics{
+		RequestsTotal: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "http_client_requests_total",
+				Help: "Total number of outbound HTTP attempts, status is the response code, error, circuit_open, canceled or deadline_exceeded",
+			},
+			[]string{"host", "method", "status"},
+		),
+
+		RequestDuration: promauto.With(registry).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "http_client_request_duration_seconds",
+				Help:    "Outbound HTTP attempt duration until the response headers in seconds",
+				Buckets: prometheus.DefBuckets,
+			},
+			[]string{"host", "method", "status"},
+		),
+
+		RetriesTotal: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "http_client_retries_total",
+				Help: "Total number of outbound HTTP retries",
+			},
+			[]string{"host"},
+		),
+
+		CircuitState: promauto.With(registry).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "http_client_circuit_state",
+				Help: "Circuit breaker state per host: 0 closed, 1 half-open, 2 open",
+			},
+			[]string{"host"},
+		),
+	}
+}
+
+// RecordRequest records an outbound attempt
+func (h *HTTPClientMetrics) RecordRequest(host, method, status string, duration time.Duration) {
+	h.RequestsTotal.WithLabelValues(host, method, status).Inc()
+	h.RequestDuration.WithLabelValues(host, method, status).Observe(duration.Seconds())
+}
+
+// RecordRetry records a retry of an outbound call
+func (h *HTTPClientMetrics) RecordRetry(host string) {
+	h.RetriesTotal.WithLabelValues(host).Inc()
+}
+
+// RecordCircuitState records a circuit breaker state change of the host
+func (h *HTTPClientMetrics) RecordCircuitState(host string, state int) {
+	h.CircuitState.WithLabelValues(host).Set(float64(state))
+}
@@ -1,24 +1,49 @@
 package metrics
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/trace"
+
+	"go-platform/pkg/config"
 )
 
+// unmatchedRoute is the path label of requests matching no route, so scanned paths add no series
+const unmatchedRoute = "unmatched"
+
 // HTTPMetrics holds HTTP-related metrics
 type HTTPMetrics struct {
 	HTTPRequestsTotal    *prometheus.CounterVec
 	HTTPRequestDuration  *prometheus.HistogramVec
+	HTTPRequestSize      *prometheus.HistogramVec
+	HTTPResponseSize     *prometheus.HistogramVec
 	HTTPRequestsInFlight prometheus.Gauge
 	HTTPErrorRate        *prometheus.CounterVec
+
+	exemplars bool
 }
 
 // NewHTTPMetrics creates a new HTTP metrics instance
-func NewHTTPMetrics(registry *prometheus.Registry) *HTTPMetrics {
+func NewHTTPMetrics(registry *prometheus.Registry, cfg config.HTTPMetricsConfig) (*HTTPMetrics, error) {
+	durationBuckets, err := parseBuckets(cfg.DurationBuckets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP duration buckets: %w", err)
+	}
+	sizeBuckets, err := parseBuckets(cfg.SizeBuckets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP size buckets: %w", err)
+	}
+
 	return &HTTPMetrics{
 		HTTPRequestsTotal: promauto.With(registry).NewCounterVec(
 			prometheus.CounterOpts{
@@ -32,7 +57,25 @@ func NewHTTPMetrics(registry *prometheus.Registry) *HTTPMetrics {
 			prometheus.HistogramOpts{
 				Name:    "http_request_duration_seconds",
 				Help:    "HTTP request duration in seconds",
-				Buckets: prometheus.DefBuckets,
+				Buckets: durationBuckets,
+			},
+			[]string{"method", "path", "status_code"},
+		),
+
+		HTTPRequestSize: promauto.With(registry).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "http_request_size_bytes",
+				Help:    "HTTP request body size in bytes",
+				Buckets: sizeBuckets,
+			},
+			[]string{"method", "path"},
+		),
+
+		HTTPResponseSize: promauto.With(registry).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "http_response_size_bytes",
+				Help:    "HTTP response body size in bytes",
+				Buckets: sizeBuckets,
 			},
 			[]string{"method", "path", "status_code"},
 		),
@@ -51,10 +94,14 @@ func NewHTTPMetrics(registry *prometheus.Registry) *HTTPMetrics {
 			},
 			[]string{"method", "path", "status_code"},
 		),
-	}
+
+		exemplars: cfg.Exemplars,
+	}, nil
 }
 
-// HTTPMiddleware creates HTTP middleware for metrics collection
+// HTTPMiddleware creates HTTP middleware for metrics collection.
+// Requests are labelled with the route template, e.g. /api/v1/dogs/{breed}/image,
+// and requests matching no route with unmatched.
 func (h *HTTPMetrics) HTTPMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -63,7 +110,14 @@ func (h *HTTPMetrics) HTTPMiddleware(next http.Handler) http.Handler {
 		h.HTTPRequestsInFlight.Inc()
 		defer h.HTTPRequestsInFlight.Dec()
 
-		// Wrap response writer to capture status code
+		// Count the body when its size is not announced
+		var body *countingBody
+		if r.ContentLength < 0 && r.Body != nil && r.Body != http.NoBody {
+			body = &countingBody{ReadCloser: r.Body}
+			r.Body = body
+		}
+
+		// Wrap response writer to capture status code and size
 		wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
 		// Call next handler
@@ -71,26 +125,123 @@ func (h *HTTPMetrics) HTTPMiddleware(next http.Handler) http.Handler {
 
 		// Record metrics
 		duration := time.Since(start).Seconds()
-		statusCode := fmt.Sprintf("%d", wrapped.statusCode)
+		statusCode := strconv.Itoa(wrapped.statusCode)
+		path := routeTemplate(r)
+		exemplar := h.exemplar(r.Context())
+
+		requestSize := max(r.ContentLength, 0)
+		if body != nil {
+			requestSize = body.n
+		}
 
 		// Track all requests
-		h.HTTPRequestsTotal.WithLabelValues(r.Method, r.URL.Path, statusCode).Inc()
-		h.HTTPRequestDuration.WithLabelValues(r.Method, r.URL.Path, statusCode).Observe(duration)
+		add(h.HTTPRequestsTotal.WithLabelValues(r.Method, path, statusCode), exemplar)
+		observe(h.HTTPRequestDuration.WithLabelValues(r.Method, path, statusCode), duration, exemplar)
+		observe(h.HTTPRequestSize.WithLabelValues(r.Method, path), float64(requestSize), exemplar)
+		observe(h.HTTPResponseSize.WithLabelValues(r.Method, path, statusCode), float64(wrapped.size), exemplar)
 
 		// Track errors (4xx, 5xx)
 		if wrapped.statusCode >= 400 {
-			h.HTTPErrorRate.WithLabelValues(r.Method, r.URL.Path, statusCode).Inc()
+			add(h.HTTPErrorRate.WithLabelValues(r.Method, path, statusCode), exemplar)
 		}
 	})
 }
 
-// responseWriter wraps http.ResponseWriter to capture status code
+// routeTemplate returns the template of the route serving r
+func routeTemplate(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return unmatchedRoute
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return unmatchedRoute
+	}
+	return template
+}
+
+// exemplar returns the trace ID of a sampled request when exemplars are enabled
+func (h *HTTPMetrics) exemplar(ctx context.Context) prometheus.Labels {
+	if !h.exemplars {
+		return nil
+	}
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsSampled() {
+		return nil
+	}
+	return prometheus.Labels{"trace_id": spanContext.TraceID().String()}
+}
+
+func add(counter prometheus.Counter, exemplar prometheus.Labels) {
+	if adder, ok := counter.(prometheus.ExemplarAdder); ok && exemplar != nil {
+		adder.AddWithExemplar(1, exemplar)
+		return
+	}
+	counter.Inc()
+}
+
+func observe(observer prometheus.Observer, value float64, exemplar prometheus.Labels) {
+	if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok && exemplar != nil {
+		exemplarObserver.ObserveWithExemplar(value, exemplar)
+		return
+	}
+	observer.Observe(value)
+}
+
+// parseBuckets parses comma separated bucket upper bounds, they are sorted as Prometheus requires
+func parseBuckets(spec string) ([]float64, error) {
+	var buckets []float64
+	for _, value := range strings.Split(spec, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		bucket, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bucket %q: %w", value, err)
+		}
+		buckets = append(buckets, bucket)
+	}
+	if len(buckets) == 0 {
+		return nil, fmt.Errorf("no buckets in %q", spec)
+	}
+
+	sort.Float64s(buckets)
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i] == buckets[i-1] {
+			return nil, fmt.Errorf("duplicate bucket %v", buckets[i])
+		}
+	}
+
+	return buckets, nil
+}
+
+// responseWriter wraps http.ResponseWriter to capture status code and body size
 type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
+	size       int64
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+func (rw *responseWriter) Write(p []byte) (int, error) {
+	n, err := rw.ResponseWriter.Write(p)
+	rw.size += int64(n)
+	return n, err
+}
+
+// countingBody counts the bytes of the request body read by the handler
+type countingBody struct {
+	io.ReadCloser
+	n int64
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseBuckets(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    []float64
+		wantErr bool
+	}{
+		{name: "sorted", spec: "0.005,0.01,0.1,1", want: []float64{0.005, 0.01, 0.1, 1}},
+		{name: "unsorted with spaces", spec: " 10, 1 ,0.5", want: []float64{0.5, 1, 10}},
+		{name: "empty entries skipped", spec: "1,,2,", want: []float64{1, 2}},
+		{name: "single bucket", spec: "100", want: []float64{100}},
+		{name: "empty", spec: "", wantErr: true},
+		{name: "only separators", spec: " , ,", wantErr: true},
+		{name: "not a number", spec: "0.1,fast", wantErr: true},
+		{name: "duplicate", spec: "1,0.5,1.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBuckets(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBuckets(%q) error = %v, want error %v", tt.spec, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("parseBuckets(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprom "go.opentelemetry.io/contrib/bridges/prometheus"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/metric/noop"
 
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -21,38 +25,114 @@ import (
 
 // Metrics holds all the application metrics
 type Metrics struct {
-	HTTP     *HTTPMetrics
-	Database *DatabaseMetrics
-	System   *SystemMetrics
+	HTTP       *HTTPMetrics
+	HTTPClient *HTTPClientMetrics
+	GRPC       *GRPCMetrics
+	Database   *DatabaseMetrics
+	Cache      *CacheMetrics
+	System     *SystemMetrics
 
 	// Prometheus registry
 	registry *prometheus.Registry
+
+	cfg           config.MetricsProviderConfig
+	meterProvider *metric.MeterProvider
 }
 
 func NewMetrics(cfg config.MetricsProviderConfig) (*Metrics, error) {
 	// Create Prometheus registry
 	registry := prometheus.NewRegistry()
 
+	httpMetrics, err := NewHTTPMetrics(registry, cfg.HTTP)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create metrics
 	metrics := &Metrics{
-		HTTP:     NewHTTPMetrics(registry),
-		Database: NewDatabaseMetrics(registry),
-		System:   NewSystemMetrics(registry),
-		registry: registry,
+		HTTP:       httpMetrics,
+		HTTPClient: NewHTTPClientMetrics(registry),
+		GRPC:       NewGRPCMetrics(registry),
+		Database:   NewDatabaseMetrics(registry),
+		Cache:      NewCacheMetrics(registry),
+		System:     NewSystemMetrics(registry),
+		registry:   registry,
+		cfg:        cfg,
 	}
 
 	return metrics, nil
 }
 
-// NewOTLPMetrics creates OpenTelemetry metrics exporter
-func NewOTLPMetrics(ctx context.Context, cfg config.MetricsProviderConfig) (*metric.MeterProvider, error) {
-	// Create OTLP exporter
-	var opts []otlpmetricgrpc.Option
-	opts = append(opts, otlpmetricgrpc.WithEndpoint(cfg.OTLPEndpoint))
-	if cfg.Insecure {
-		opts = append(opts, otlpmetricgrpc.WithInsecure())
+// Start starts system metrics collection and the exporters listed in the config.
+// Collection and the Prometheus server run until ctx is cancelled, the OTLP push until Shutdown.
+func (m *Metrics) Start(ctx context.Context) error {
+	exporters, err := parseExporters(m.cfg.MetricsExporters)
+	if err != nil {
+		return err
+	}
+
+	go m.System.StartCollection(ctx)
+
+	if exporters[exporterOTLP] {
+		meterProvider, err := NewOTLPMetrics(ctx, m.cfg, m.registry)
+		if err != nil {
+			return err
+		}
+		m.meterProvider = meterProvider
 	}
-	exporter, err := otlpmetricgrpc.New(ctx, opts...)
+
+	if exporters[exporterPrometheus] {
+		go func() {
+			if err := m.StartPrometheusServer(ctx, m.cfg.PrometheusPort); err != nil {
+				slog.Error("Prometheus metrics server error", "error", err)
+			}
+		}()
+	}
+
+	return nil
+}
+
+// Shutdown pushes the last OTLP metrics and stops the MeterProvider.
+// The global MeterProvider is replaced by a no-op one so late instruments do not use the stopped provider.
+func (m *Metrics) Shutdown(ctx context.Context) error {
+	if m.meterProvider == nil {
+		return nil
+	}
+	otel.SetMeterProvider(noop.NewMeterProvider())
+	return m.meterProvider.Shutdown(ctx)
+}
+
+// Metric exporters accepted in MetricsProviderConfig.MetricsExporters
+const (
+	exporterPrometheus = "prometheus"
+	exporterOTLP       = "otlp"
+	exporterNone       = "none"
+)
+
+// parseExporters parses the comma separated exporter list, none alone disables every exporter
+func parseExporters(spec string) (map[string]bool, error) {
+	exporters := make(map[string]bool)
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "":
+		case exporterPrometheus, exporterOTLP, exporterNone:
+			exporters[name] = true
+		default:
+			return nil, fmt.Errorf("unknown metrics exporter %q", name)
+		}
+	}
+	if exporters[exporterNone] && len(exporters) > 1 {
+		return nil, fmt.Errorf("metrics exporter none cannot be combined with others: %q", spec)
+	}
+
+	return exporters, nil
+}
+
+// NewOTLPMetrics creates a MeterProvider pushing the instruments of the global MeterProvider
+// and every metric of the Prometheus registry, through the bridge, to the OTLP collector
+func NewOTLPMetrics(ctx context.Context, cfg config.MetricsProviderConfig, gatherer prometheus.Gatherer) (*metric.MeterProvider, error) {
+	exporter, err := newOTLPExporter(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create OTLP metrics exporter: %w", err)
 	}
@@ -70,22 +150,61 @@ func NewOTLPMetrics(ctx context.Context, cfg config.MetricsProviderConfig) (*met
 	}
 
 	// Create meter provider
+	reader := metric.NewPeriodicReader(exporter,
+		metric.WithInterval(cfg.MetricsInterval),
+		metric.WithProducer(otelprom.NewMetricProducer(otelprom.WithGatherer(gatherer))),
+	)
 	meterProvider := metric.NewMeterProvider(
-		metric.WithReader(metric.NewPeriodicReader(exporter)),
+		metric.WithReader(reader),
 		metric.WithResource(res),
 	)
 
 	// Set global meter provider
 	otel.SetMeterProvider(meterProvider)
 
-	slog.Info("OpenTelemetry metrics initialized", "endpoint", cfg.OTLPEndpoint)
+	slog.Info("OpenTelemetry metrics initialized", "endpoint", cfg.OTLPEndpoint, "protocol", cfg.OTLPProtocol, "interval", cfg.MetricsInterval)
 	return meterProvider, nil
 }
 
+// newOTLPExporter creates the metric exporter of the configured OTLP protocol.
+// It connects lazily, an unreachable collector does not fail startup.
+func newOTLPExporter(ctx context.Context, cfg config.MetricsProviderConfig) (metric.Exporter, error) {
+	// the endpoint may be a URL as in the standard OTEL_EXPORTER_OTLP_ENDPOINT or host:port
+	withURL := strings.Contains(cfg.OTLPEndpoint, "://")
+
+	switch cfg.OTLPProtocol {
+	case "grpc":
+		var opts []otlpmetricgrpc.Option
+		if withURL {
+			opts = append(opts, otlpmetricgrpc.WithEndpointURL(cfg.OTLPEndpoint))
+		} else {
+			opts = append(opts, otlpmetricgrpc.WithEndpoint(cfg.OTLPEndpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlpmetricgrpc.WithInsecure())
+		}
+		return otlpmetricgrpc.New(ctx, opts...)
+	case "http/protobuf":
+		var opts []otlpmetrichttp.Option
+		if withURL {
+			opts = append(opts, otlpmetrichttp.WithEndpointURL(cfg.OTLPEndpoint))
+		} else {
+			opts = append(opts, otlpmetrichttp.WithEndpoint(cfg.OTLPEndpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlpmetrichttp.WithInsecure())
+		}
+		return otlpmetrichttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown OTLP protocol %q", cfg.OTLPProtocol)
+	}
+}
+
 // StartPrometheusServer starts the Prometheus metrics server
 func (m *Metrics) StartPrometheusServer(ctx context.Context, port string) error {
 	// Create HTTP handler for Prometheus metrics
-	handler := promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+	// exemplars are only carried by the OpenMetrics format
+	handler := promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{EnableOpenMetrics: m.cfg.HTTP.Exemplars})
 
 	// Create HTTP server
 	server := &http.Server{
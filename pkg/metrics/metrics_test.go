@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go-platform/pkg/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+func TestShutdownFlushesOTLPMetrics(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		exports [][]byte
+	)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		exports = append(exports, body)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	defer collector.Close()
+
+	previous := otel.GetMeterProvider()
+	t.Cleanup(func() { otel.SetMeterProvider(previous) })
+
+	m, err := NewMetrics(config.MetricsProviderConfig{
+		ServiceName:      "go-platform",
+		OTLPEndpoint:     collector.URL,
+		OTLPProtocol:     "http/protobuf",
+		Insecure:         true,
+		MetricsExporters: "otlp",
+		// nothing is pushed before Shutdown
+		MetricsInterval: time.Hour,
+		HTTP:            config.HTTPMetricsConfig{DurationBuckets: "0.1,1", SizeBuckets: "100,1000"},
+	})
+	if err != nil {
+		t.Fatalf("NewMetrics() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := m.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	m.GRPC.StartedTotal.WithLabelValues(grpcUnary, "go_platform.dogs.DogService", "GetDog").Inc()
+
+	if err := m.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(exports) != 1 {
+		t.Fatalf("collector got %d exports, want 1", len(exports))
+	}
+	// registry metrics reach the collector through the Prometheus bridge
+	if !bytes.Contains(exports[0], []byte("grpc_server_started_total")) {
+		t.Fatal("export does not carry grpc_server_started_total")
+	}
+	if _, ok := otel.GetMeterProvider().(noop.MeterProvider); !ok {
+		t.Fatalf("global MeterProvider = %T after Shutdown, want noop.MeterProvider", otel.GetMeterProvider())
+	}
+}
+
+func TestParseExporters(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []string
+		wantErr bool
+	}{
+		{spec: "prometheus", want: []string{exporterPrometheus}},
+		{spec: " Prometheus , otlp", want: []string{exporterPrometheus, exporterOTLP}},
+		{spec: "none", want: []string{exporterNone}},
+		{spec: "none,otlp", wantErr: true},
+		{spec: "statsd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseExporters(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseExporters(%q) error = %v, want error %v", tt.spec, err, tt.wantErr)
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("parseExporters(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+		for _, name := range tt.want {
+			if !got[name] {
+				t.Fatalf("parseExporters(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		}
+	}
+}
@@ -51,6 +51,7 @@ func (s *SystemMetrics) StartCollection(ctx context.Context) {
 	defer ticker.Stop()
 
 	slog.Info("Starting system metrics collection", "interval", "15s")
+	s.collectSystemMetrics()
 
 	for {
 		select {
@@ -0,0 +1,51 @@
+// Package requestid carries the ID of the request being served through its context,
+// so logs, error responses and outbound calls of one request can be correlated.
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Header is the HTTP header carrying the request ID
+	Header = "X-Request-ID"
+	// MetadataKey is the gRPC metadata key carrying the request ID
+	MetadataKey = "x-request-id"
+)
+
+// maxLength bounds the IDs accepted from clients, a longer value is replaced
+const maxLength = 128
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying id
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, empty when there is none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// New generates a request ID
+func New() string {
+	return uuid.New().String()
+}
+
+// Resolve returns the ID sent by the client when it is usable, a generated one otherwise.
+// IDs are echoed in headers and logs, so only short printable ASCII values are kept.
+func Resolve(id string) string {
+	if id == "" || len(id) > maxLength {
+		return New()
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return New()
+		}
+	}
+	return id
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"sync"
 	"time"
 
 	"go-platform/pkg/config"
@@ -13,20 +14,36 @@ import (
 	"go-platform/pkg/tracer"
 )
 
+// flushTimeout bounds the final push of metrics and spans after the servers have stopped
+const flushTimeout = 5 * time.Second
+
 // GRPCServer interface for gRPC server operations
 type GRPCServer interface {
 	Serve(net.Listener) error
 	GracefulStop()
+	Stop()
+}
+
+// Worker is a background job running for the server lifetime, e.g. the outbox relay.
+// Run must return once ctx is cancelled.
+type Worker interface {
+	Run(ctx context.Context)
 }
 
 // Server holds both HTTP and gRPC servers with their configurations
 type Server struct {
 	HTTP         *http.Server
 	GRPC         GRPCServer
+	Workers      []Worker
 	Metrics      *metrics.Metrics
 	Tracer       *tracer.Tracer
 	Config       *config.Config
 	ServerConfig ServerConfig
+
+	requests       context.Context
+	cancelRequests context.CancelFunc
+	stopWorkers    context.CancelFunc
+	workersWG      sync.WaitGroup
 }
 
 // ServerConfig holds server-specific configuration
@@ -46,6 +63,9 @@ func NewServer(cfg *config.Config, httpHandler http.Handler, grpcServer GRPCServ
 		return nil, fmt.Errorf("failed to initialize tracer: %w", err)
 	}
 
+	// Every request context derives from it so shutdown can cancel the calls still in flight
+	requests, cancelRequests := context.WithCancel(context.Background())
+
 	// Create HTTP server with metrics middleware
 	httpServer := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.Server.HTTPPort),
@@ -53,14 +73,19 @@ func NewServer(cfg *config.Config, httpHandler http.Handler, grpcServer GRPCServ
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
+		BaseContext: func(net.Listener) context.Context {
+			return requests
+		},
 	}
 
 	return &Server{
-		HTTP:    httpServer,
-		GRPC:    grpcServer,
-		Metrics: metricsInstance,
-		Tracer:  tracerInstance,
-		Config:  cfg,
+		HTTP:           httpServer,
+		GRPC:           grpcServer,
+		Metrics:        metricsInstance,
+		Tracer:         tracerInstance,
+		Config:         cfg,
+		requests:       requests,
+		cancelRequests: cancelRequests,
 		ServerConfig: ServerConfig{
 			HTTPPort:    cfg.Server.HTTPPort,
 			GRPCPort:    cfg.Server.GRPCPort,
@@ -69,14 +94,18 @@ func NewServer(cfg *config.Config, httpHandler http.Handler, grpcServer GRPCServ
 	}, nil
 }
 
+// RequestContext returns the parent of the request contexts, gRPC servers derive their calls from it.
+// It is cancelled when shutdown stops waiting for the requests in flight.
+func (s *Server) RequestContext() context.Context {
+	return s.requests
+}
+
 // Start starts both HTTP and gRPC servers
 func (s *Server) Start(ctx context.Context) error {
-	// Start system metrics collection
-	go func() {
-		if err := s.Metrics.StartPrometheusServer(ctx, s.ServerConfig.MetricsPort); err != nil {
-			slog.Error("Prometheus metrics server error", "error", err)
-		}
-	}()
+	// Start system metrics collection and the metrics exporters
+	if err := s.Metrics.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start metrics: %w", err)
+	}
 
 	// Start gRPC server
 	go func() {
@@ -92,6 +121,17 @@ func (s *Server) Start(ctx context.Context) error {
 		}
 	}()
 
+	// Start background workers
+	workersCtx, stopWorkers := context.WithCancel(ctx)
+	s.stopWorkers = stopWorkers
+	for _, worker := range s.Workers {
+		s.workersWG.Add(1)
+		go func() {
+			defer s.workersWG.Done()
+			worker.Run(workersCtx)
+		}()
+	}
+
 	// Start HTTP server
 	go func() {
 		slog.Info("Starting HTTP server", "port", s.ServerConfig.HTTPPort)
@@ -112,16 +152,48 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	shutdownCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
+	// Requests still running when the drain deadline passes are cancelled,
+	// so a stuck upstream call does not hold the shutdown
+	defer s.cancelRequests()
+	stopCancel := context.AfterFunc(shutdownCtx, s.cancelRequests)
+	defer stopCancel()
+
 	// Shutdown HTTP server
 	if err := s.HTTP.Shutdown(shutdownCtx); err != nil {
 		slog.Error("HTTP server shutdown error", "error", err)
 	}
 
-	// Shutdown gRPC server
-	s.GRPC.GracefulStop()
+	// Shutdown gRPC server, closing the remaining streams once the deadline passes
+	stopped := make(chan struct{})
+	go func() {
+		s.GRPC.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		slog.Warn("gRPC graceful stop timed out, closing connections")
+		s.GRPC.Stop()
+		<-stopped
+	}
+
+	// Stop background workers after in-flight requests are done
+	if s.stopWorkers != nil {
+		s.stopWorkers()
+		s.workersWG.Wait()
+	}
+
+	// The drain may have used the whole deadline, the last metrics and spans get their own
+	flushCtx, cancelFlush := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+	defer cancelFlush()
+
+	// Push the last metrics
+	if err := s.Metrics.Shutdown(flushCtx); err != nil {
+		slog.Error("Metrics shutdown error", "error", err)
+	}
 
 	// Shutdown tracer
-	if err := s.Tracer.Shutdown(shutdownCtx); err != nil {
+	if err := s.Tracer.Shutdown(flushCtx); err != nil {
 		slog.Error("Tracer shutdown error", "error", err)
 	}
 
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go-platform/pkg/config"
+	"go-platform/pkg/metrics"
+)
+
+// stuckGRPCServer has a call that never finishes, GracefulStop waits until Stop closes it
+type stuckGRPCServer struct {
+	once    sync.Once
+	stopped chan struct{}
+}
+
+func (s *stuckGRPCServer) Serve(net.Listener) error { return nil }
+
+func (s *stuckGRPCServer) GracefulStop() { <-s.stopped }
+
+func (s *stuckGRPCServer) Stop() { s.once.Do(func() { close(s.stopped) }) }
+
+// drainWorker reports whether the request in flight had finished by the time workers were stopped
+type drainWorker struct {
+	request <-chan error
+	result  chan error
+}
+
+func (w *drainWorker) Run(ctx context.Context) {
+	<-ctx.Done()
+	select {
+	case err := <-w.request:
+		w.result <- err
+	case <-time.After(time.Second):
+		w.result <- errors.New("request still running")
+	}
+}
+
+func TestShutdownCancelsStuckRequests(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(10 * time.Second):
+		}
+	}))
+	defer upstream.Close()
+
+	started := make(chan struct{})
+	upstreamErr := make(chan error, 1)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, upstream.URL, nil)
+		if err != nil {
+			upstreamErr <- err
+			return
+		}
+		close(started)
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		upstreamErr <- err
+	})
+
+	// reserve a free port for the HTTP server started by Start
+	reserved, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	addr := reserved.Addr().(*net.TCPAddr)
+	reserved.Close()
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{HTTPPort: strconv.Itoa(addr.Port), GRPCPort: "0"},
+		MetricsProvider: config.MetricsProviderConfig{
+			MetricsExporters: "none",
+			HTTP:             config.HTTPMetricsConfig{DurationBuckets: "0.1,1", SizeBuckets: "100,1000"},
+			Tracing:          config.TracingConfig{Exporter: "none", Sampler: "always_on"},
+		},
+	}
+	metricsInstance, err := metrics.NewMetrics(cfg.MetricsProvider)
+	if err != nil {
+		t.Fatalf("NewMetrics() error = %v", err)
+	}
+	grpcServer := &stuckGRPCServer{stopped: make(chan struct{})}
+	s, err := NewServer(cfg, handler, grpcServer, metricsInstance)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	worker := &drainWorker{request: upstreamErr, result: make(chan error, 1)}
+	s.Workers = append(s.Workers, worker)
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	// the HTTP server listens in the background, retry until it accepts the request
+	go func() {
+		for range 50 {
+			resp, err := http.Get("http://" + addr.String())
+			if err == nil {
+				resp.Body.Close()
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("request did not reach the handler")
+	}
+
+	const deadline = 200 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), deadline)
+	defer cancel()
+
+	begin := time.Now()
+	if err := s.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if elapsed := time.Since(begin); elapsed > deadline+time.Second {
+		t.Fatalf("Shutdown() took %v, want about %v", elapsed, deadline)
+	}
+
+	if err := <-worker.result; !errors.Is(err, context.Canceled) {
+		t.Fatalf("upstream call error = %v, want %v", err, context.Canceled)
+	}
+
+	select {
+	case <-grpcServer.stopped:
+	default:
+		t.Fatal("gRPC server was not stopped after the drain deadline")
+	}
+}
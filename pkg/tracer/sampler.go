@@ -0,0 +1,152 @@
+package tracer
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+
+	"go-platform/pkg/config"
+)
+
+// Sampling attributes are added to the spans sampled by a ratio, with a parent-based sampler
+// only to the spans starting a trace. A tail sampler or the backend can tell which rule
+// kept the trace and extrapolate counts from the ratio.
+const (
+	samplingRuleKey  = attribute.Key("sampling.rule")
+	samplingRatioKey = attribute.Key("sampling.ratio")
+)
+
+// newSampler builds the sampler named in cfg with the sampling rules applied to new traces.
+// Invalid rules are skipped and returned, so they can be reported.
+func newSampler(cfg config.TracingConfig) (sdktrace.Sampler, []string, error) {
+	name := strings.ToLower(strings.TrimSpace(cfg.Sampler))
+	name, parentBased := strings.CutPrefix(name, "parentbased_")
+
+	var ratio float64
+	switch name {
+	case "always_on":
+		ratio = 1
+	case "always_off":
+		ratio = 0
+	case "traceidratio":
+		ratio = cfg.SamplerArg
+	default:
+		return nil, nil, fmt.Errorf("unknown trace sampler %q", cfg.Sampler)
+	}
+
+	rules, invalid := parseSamplingRules(cfg.SamplingRules)
+	var root sdktrace.Sampler = ruleSampler{rules: rules, fallback: newRatioSampler(ratio)}
+	if parentBased {
+		root = sdktrace.ParentBased(root)
+	}
+
+	return root, invalid, nil
+}
+
+// parseSamplingRules parses "prefix=ratio,..." rules, the longest prefix is matched first
+func parseSamplingRules(spec string) ([]samplingRule, []string) {
+	var (
+		rules   []samplingRule
+		invalid []string
+	)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		prefix, value, found := strings.Cut(entry, "=")
+		prefix = strings.TrimSpace(prefix)
+		ratio, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if !found || !strings.HasPrefix(prefix, "/") || err != nil || ratio < 0 || ratio > 1 {
+			invalid = append(invalid, entry)
+			continue
+		}
+
+		rules = append(rules, samplingRule{prefix: prefix, sampler: newRatioSampler(ratio)})
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		return len(rules[i].prefix) > len(rules[j].prefix)
+	})
+
+	return rules, invalid
+}
+
+// samplingRule samples the spans whose target starts with prefix
+type samplingRule struct {
+	prefix  string
+	sampler sdktrace.Sampler
+}
+
+// ruleSampler samples a span by the first rule matching its target, the path of
+// an HTTP request or /service/method of a gRPC call, and by fallback otherwise
+type ruleSampler struct {
+	rules    []samplingRule
+	fallback sdktrace.Sampler
+}
+
+func (s ruleSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if target := samplingTarget(p.Attributes); target != "" {
+		for _, rule := range s.rules {
+			if strings.HasPrefix(target, rule.prefix) {
+				result := rule.sampler.ShouldSample(p)
+				if result.Decision == sdktrace.RecordAndSample {
+					result.Attributes = append(result.Attributes, samplingRuleKey.String(rule.prefix))
+				}
+				return result
+			}
+		}
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s ruleSampler) Description() string {
+	return fmt.Sprintf("RuleSampler{rules:%d,fallback:%s}", len(s.rules), s.fallback.Description())
+}
+
+// samplingTarget returns the target of a server span from the attributes set at its start
+func samplingTarget(attrs []attribute.KeyValue) string {
+	var service, method string
+	for _, attr := range attrs {
+		switch attr.Key {
+		case semconv.URLPathKey:
+			return attr.Value.AsString()
+		case semconv.RPCServiceKey:
+			service = attr.Value.AsString()
+		case semconv.RPCMethodKey:
+			method = attr.Value.AsString()
+		}
+	}
+	if service == "" {
+		return ""
+	}
+	return "/" + service + "/" + method
+}
+
+// ratioSampler samples a ratio of traces by trace ID and records the ratio on sampled spans
+type ratioSampler struct {
+	ratio   float64
+	sampler sdktrace.Sampler
+}
+
+func newRatioSampler(ratio float64) ratioSampler {
+	return ratioSampler{ratio: ratio, sampler: sdktrace.TraceIDRatioBased(ratio)}
+}
+
+func (s ratioSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	result := s.sampler.ShouldSample(p)
+	if result.Decision == sdktrace.RecordAndSample {
+		result.Attributes = append(result.Attributes, samplingRatioKey.Float64(s.ratio))
+	}
+	return result
+}
+
+func (s ratioSampler) Description() string {
+	return s.sampler.Description()
+}
@@ -0,0 +1,139 @@
+package tracer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"go-platform/pkg/config"
+)
+
+func TestParseSamplingRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        string
+		wantPrefix  []string
+		wantRatio   []float64
+		wantInvalid []string
+	}{
+		{name: "empty", spec: ""},
+		{
+			name:       "longest prefix first",
+			spec:       "/api=0.5, /api/v1/dogs=0.1 ,/live=0",
+			wantPrefix: []string{"/api/v1/dogs", "/live", "/api"},
+			wantRatio:  []float64{0.1, 0, 0.5},
+		},
+		{
+			name:       "gRPC method",
+			spec:       "/dogs.v1.DogService/ListDogs=1",
+			wantPrefix: []string{"/dogs.v1.DogService/ListDogs"},
+			wantRatio:  []float64{1},
+		},
+		{
+			name:        "invalid entries",
+			spec:        "api=0.5,/api=2,/api=-0.1,/api=half,/api,,/ok=0.25",
+			wantPrefix:  []string{"/ok"},
+			wantRatio:   []float64{0.25},
+			wantInvalid: []string{"api=0.5", "/api=2", "/api=-0.1", "/api=half", "/api"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, invalid := parseSamplingRules(tt.spec)
+
+			var prefixes []string
+			var ratios []float64
+			for _, rule := range rules {
+				prefixes = append(prefixes, rule.prefix)
+				ratios = append(ratios, rule.sampler.(ratioSampler).ratio)
+			}
+			if !slices.Equal(prefixes, tt.wantPrefix) || !slices.Equal(ratios, tt.wantRatio) {
+				t.Fatalf("parseSamplingRules() = %v %v, want %v %v", prefixes, ratios, tt.wantPrefix, tt.wantRatio)
+			}
+			if !slices.Equal(invalid, tt.wantInvalid) {
+				t.Fatalf("parseSamplingRules() invalid = %q, want %q", invalid, tt.wantInvalid)
+			}
+		})
+	}
+}
+
+func TestRuleSampler(t *testing.T) {
+	rules, _ := parseSamplingRules("/live=0,/api/v1/dogs=1,/api=0,/dogs.v1.DogService/ListDogs=0")
+	sampler := ruleSampler{rules: rules, fallback: newRatioSampler(1)}
+
+	tests := []struct {
+		name         string
+		attrs        []attribute.KeyValue
+		wantDecision sdktrace.SamplingDecision
+		wantRule     string
+	}{
+		{name: "matching rule", attrs: []attribute.KeyValue{semconv.URLPath("/api/v1/dogs/hound/image")}, wantDecision: sdktrace.RecordAndSample, wantRule: "/api/v1/dogs"},
+		{name: "longest prefix wins", attrs: []attribute.KeyValue{semconv.URLPath("/api/v1/breeds")}, wantDecision: sdktrace.Drop},
+		{name: "dropped path", attrs: []attribute.KeyValue{semconv.URLPath("/live")}, wantDecision: sdktrace.Drop},
+		{name: "gRPC method", attrs: []attribute.KeyValue{semconv.RPCService("dogs.v1.DogService"), semconv.RPCMethod("ListDogs")}, wantDecision: sdktrace.Drop},
+		{name: "other gRPC method falls back", attrs: []attribute.KeyValue{semconv.RPCService("dogs.v1.DogService"), semconv.RPCMethod("GetDog")}, wantDecision: sdktrace.RecordAndSample},
+		{name: "no target falls back", attrs: nil, wantDecision: sdktrace.RecordAndSample},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := sampler.ShouldSample(sdktrace.SamplingParameters{
+				TraceID:    trace.TraceID{0x01},
+				Name:       "span",
+				Attributes: tt.attrs,
+			})
+			if result.Decision != tt.wantDecision {
+				t.Fatalf("ShouldSample() decision = %v, want %v", result.Decision, tt.wantDecision)
+			}
+
+			var rule string
+			for _, attr := range result.Attributes {
+				if attr.Key == samplingRuleKey {
+					rule = attr.Value.AsString()
+				}
+			}
+			if rule != tt.wantRule {
+				t.Fatalf("ShouldSample() %s = %q, want %q", samplingRuleKey, rule, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestNewSampler(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             config.TracingConfig
+		wantParentBased bool
+		wantInvalid     []string
+		wantErr         bool
+	}{
+		{name: "always on", cfg: config.TracingConfig{Sampler: "always_on"}},
+		{name: "parent based ratio", cfg: config.TracingConfig{Sampler: " ParentBased_TraceIDRatio", SamplerArg: 0.5}, wantParentBased: true},
+		{name: "invalid rules reported", cfg: config.TracingConfig{Sampler: "always_off", SamplingRules: "/live=0,live=0"}, wantInvalid: []string{"live=0"}},
+		{name: "unknown", cfg: config.TracingConfig{Sampler: "sometimes"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampler, invalid, err := newSampler(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newSampler() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := strings.HasPrefix(sampler.Description(), "ParentBased"); got != tt.wantParentBased {
+				t.Fatalf("newSampler() = %s, want parent based %v", sampler.Description(), tt.wantParentBased)
+			}
+			if !slices.Equal(invalid, tt.wantInvalid) {
+				t.Fatalf("newSampler() invalid = %q, want %q", invalid, tt.wantInvalid)
+			}
+		})
+	}
+}
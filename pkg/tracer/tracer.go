@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -24,17 +27,18 @@ type Tracer struct {
 	shutdown       func(context.Context) error
 }
 
-// NewTracer creates a new OpenTelemetry tracer
+// NewTracer creates a new OpenTelemetry tracer.
+// Spans go to the exporter selected in the config; with the none exporter they are created
+// but dropped, so the service runs without a collector and its logs still carry trace IDs.
 func NewTracer(ctx context.Context, cfg config.MetricsProviderConfig) (*Tracer, error) {
-	// Create OTLP exporter
-	var opts []otlptracegrpc.Option
-	opts = append(opts, otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint))
-	if cfg.Insecure {
-		opts = append(opts, otlptracegrpc.WithInsecure())
+	sampler, invalidRules, err := newSampler(cfg.Tracing)
+	if err != nil {
+		return nil, err
 	}
-	exporter, err := otlptracegrpc.New(ctx, opts...)
+
+	exporter, err := newExporter(ctx, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		return nil, err
 	}
 
 	// Create resource with service information
@@ -50,11 +54,14 @@ func NewTracer(ctx context.Context, cfg config.MetricsProviderConfig) (*Tracer,
 	}
 
 	// Create tracer provider
-	tracerProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
+	opts := []sdktrace.TracerProviderOption{
 		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.TraceIDRatioBased(1.0)), // 100% sampling for development
-	)
+		sdktrace.WithSampler(sampler),
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	tracerProvider := sdktrace.NewTracerProvider(opts...)
 
 	// Set global tracer provider
 	otel.SetTracerProvider(tracerProvider)
@@ -65,10 +72,15 @@ func NewTracer(ctx context.Context, cfg config.MetricsProviderConfig) (*Tracer,
 		propagation.Baggage{},
 	))
 
+	if len(invalidRules) > 0 {
+		slog.Warn("Ignoring invalid trace sampling rules", "rules", invalidRules)
+	}
 	slog.Info("OpenTelemetry tracer initialized",
 		"service", cfg.ServiceName,
 		"version", cfg.ServiceVersion,
+		"exporter", cfg.Tracing.Exporter,
 		"endpoint", cfg.OTLPEndpoint,
+		"sampler", sampler.Description(),
 	)
 
 	return &Tracer{
@@ -77,6 +89,59 @@ func NewTracer(ctx context.Context, cfg config.MetricsProviderConfig) (*Tracer,
 	}, nil
 }
 
+// newExporter creates the span exporter selected in the config, nil for none.
+// OTLP exporters connect lazily, an unreachable collector does not fail startup.
+func newExporter(ctx context.Context, cfg config.MetricsProviderConfig) (sdktrace.SpanExporter, error) {
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+
+	switch strings.ToLower(cfg.Tracing.Exporter) {
+	case "none":
+		return nil, nil
+	case "stdout":
+		exporter, err = stdouttrace.New()
+	case "otlp":
+		// the endpoint may be a URL as in the standard OTEL_EXPORTER_OTLP_ENDPOINT or host:port
+		withURL := strings.Contains(cfg.OTLPEndpoint, "://")
+
+		switch cfg.OTLPProtocol {
+		case "grpc":
+			var opts []otlptracegrpc.Option
+			if withURL {
+				opts = append(opts, otlptracegrpc.WithEndpointURL(cfg.OTLPEndpoint))
+			} else {
+				opts = append(opts, otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint))
+			}
+			if cfg.Insecure {
+				opts = append(opts, otlptracegrpc.WithInsecure())
+			}
+			exporter, err = otlptracegrpc.New(ctx, opts...)
+		case "http/protobuf":
+			var opts []otlptracehttp.Option
+			if withURL {
+				opts = append(opts, otlptracehttp.WithEndpointURL(cfg.OTLPEndpoint))
+			} else {
+				opts = append(opts, otlptracehttp.WithEndpoint(cfg.OTLPEndpoint))
+			}
+			if cfg.Insecure {
+				opts = append(opts, otlptracehttp.WithInsecure())
+			}
+			exporter, err = otlptracehttp.New(ctx, opts...)
+		default:
+			return nil, fmt.Errorf("unknown OTLP protocol %q", cfg.OTLPProtocol)
+		}
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", cfg.Tracing.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", cfg.Tracing.Exporter, err)
+	}
+
+	return exporter, nil
+}
+
 // Shutdown gracefully shuts down the tracer
 func (t *Tracer) Shutdown(ctx context.Context) error {
 	if t.shutdown != nil {
@@ -111,3 +176,36 @@ func SetSpanError(span trace.Span, err error) {
 	span.RecordError(err)
 	span.SetStatus(codes.Error, err.Error())
 }
+
+// EndSpan marks the span as failed when err is set and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		SetSpanError(span, err)
+	}
+	span.End()
+}
+
+// StartDBSpan starts a client span of a SQL statement sent to the database system.
+// The span is named after the operation, the first keyword of the statement,
+// and the statement is recorded with its whitespace collapsed.
+// Outside of a trace no span is started and the span of ctx, a no-op, is returned.
+func StartDBSpan(ctx context.Context, system, statement string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	// statements outside of a trace, e.g. of pollers, would each start a trace of their own
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	statement = strings.Join(strings.Fields(statement), " ")
+	operation, _, _ := strings.Cut(statement, " ")
+	operation = strings.ToUpper(operation)
+
+	opts = append(opts,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String(system),
+			semconv.DBOperation(operation),
+			semconv.DBStatement(statement),
+		),
+	)
+	return StartSpan(ctx, operation, opts...)
+}
@@ -0,0 +1,120 @@
+// Package errs classifies errors by kind, so the HTTP and gRPC layers map a failure
+// to a status the same way no matter which client, repository or service raised it.
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// Kind is the class of a failure as seen by the caller of the service
+type Kind int
+
+const (
+	// Unknown is an unexpected failure of the service itself
+	Unknown Kind = iota
+	// NotFound is a missing dog, breed, job or object
+	NotFound
+	// InvalidArgument is a request the service cannot serve as sent
+	InvalidArgument
+	// Conflict is a request that clashes with the current state, retrying it may succeed
+	Conflict
+	// Unavailable is the service refusing work it has no capacity for
+	Unavailable
+	// UpstreamUnavailable is a failed or refused call to the dog API or another dependency
+	UpstreamUnavailable
+	// Timeout is a deadline that passed before the work finished
+	Timeout
+	// Canceled is work abandoned because the caller went away or the service shuts down
+	Canceled
+)
+
+var kindNames = map[Kind]string{
+	Unknown:             "UNKNOWN",
+	NotFound:            "NOT_FOUND",
+	InvalidArgument:     "INVALID_ARGUMENT",
+	Conflict:            "CONFLICT",
+	Unavailable:         "UNAVAILABLE",
+	UpstreamUnavailable: "UPSTREAM_UNAVAILABLE",
+	Timeout:             "TIMEOUT",
+	Canceled:            "CANCELED",
+}
+
+// String is the kind in upper snake case, used as the reason of error details
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return kindNames[Unknown]
+}
+
+// Error is an error of a known kind
+type Error struct {
+	kind Kind
+	err  error
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func (e *Error) Kind() Kind {
+	return e.kind
+}
+
+// New creates an error of the kind, meant for sentinel errors compared with errors.Is
+func New(kind Kind, msg string) error {
+	return &Error{kind: kind, err: errors.New(msg)}
+}
+
+// Errorf formats an error of the kind, %w keeps the wrapped error reachable
+func Errorf(kind Kind, format string, args ...any) error {
+	return &Error{kind: kind, err: fmt.Errorf(format, args...)}
+}
+
+// Wrap marks err with the kind, a nil err stays nil
+func Wrap(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{kind: kind, err: err}
+}
+
+// KindOf returns the kind of err. A cancelled or expired context wins over the kind
+// it was wrapped in, since the work was abandoned rather than failed; otherwise
+// the outermost kind is used.
+func KindOf(err error) Kind {
+	if err == nil {
+		return Unknown
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return Timeout
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e.kind
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return Timeout
+	}
+
+	return Unknown
+}
+
+// Is reports whether err is of the kind
+func Is(err error, kind Kind) bool {
+	return err != nil && KindOf(err) == kind
+}
@@ -0,0 +1,85 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+)
+
+var errNotFound = New(NotFound, "dog not found")
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Kind
+	}{
+		{name: "nil", err: nil, want: Unknown},
+		{name: "plain error", err: errors.New("boom"), want: Unknown},
+		{name: "sentinel", err: errNotFound, want: NotFound},
+		{name: "wrapped sentinel", err: fmt.Errorf("failed to get dog: %w", errNotFound), want: NotFound},
+		{name: "errorf", err: Errorf(Conflict, "dog %s changed", "1"), want: Conflict},
+		{name: "outermost kind wins", err: Wrap(UpstreamUnavailable, errNotFound), want: UpstreamUnavailable},
+		{name: "canceled context", err: context.Canceled, want: Canceled},
+		{name: "canceled wins over the kind", err: Wrap(UpstreamUnavailable, fmt.Errorf("call: %w", context.Canceled)), want: Canceled},
+		{name: "deadline wins over the kind", err: Errorf(Unavailable, "call: %w", context.DeadlineExceeded), want: Timeout},
+		{name: "network timeout", err: &net.OpError{Op: "dial", Err: os.ErrDeadlineExceeded}, want: Timeout},
+		{name: "network failure", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, want: Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KindOf(tt.err); got != tt.want {
+				t.Fatalf("KindOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapNil(t *testing.T) {
+	if err := Wrap(NotFound, nil); err != nil {
+		t.Fatalf("Wrap(nil) = %v, want nil", err)
+	}
+}
+
+func TestIs(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind Kind
+		want bool
+	}{
+		{name: "same kind", err: errNotFound, kind: NotFound, want: true},
+		{name: "other kind", err: errNotFound, kind: Conflict, want: false},
+		{name: "nil is not unknown", err: nil, kind: Unknown, want: false},
+		{name: "plain error is unknown", err: errors.New("boom"), kind: Unknown, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Is(tt.err, tt.kind); got != tt.want {
+				t.Fatalf("Is(%v, %v) = %v, want %v", tt.err, tt.kind, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKindString(t *testing.T) {
+	tests := []struct {
+		kind Kind
+		want string
+	}{
+		{kind: NotFound, want: "NOT_FOUND"},
+		{kind: UpstreamUnavailable, want: "UPSTREAM_UNAVAILABLE"},
+		{kind: Kind(100), want: "UNKNOWN"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.kind.String(); got != tt.want {
+			t.Errorf("Kind(%d).String() = %q, want %q", int(tt.kind), got, tt.want)
+		}
+	}
+}
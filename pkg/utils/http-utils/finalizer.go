@@ -4,10 +4,15 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/google/uuid"
+	"go-platform/pkg/requestid"
+	"go-platform/pkg/utils/errs"
+
 	jsoniter "github.com/json-iterator/go"
 )
 
+// StatusClientClosedRequest is reported when the client went away before the response, as nginx does
+const StatusClientClosedRequest = 499
+
 type Status struct {
 	Status string `json:"status"`
 }
@@ -25,15 +30,38 @@ type ErrorResponse struct {
 	RequestID    string        `json:"request_id"`
 }
 
+// kindStatuses is the HTTP status of every error kind, errors without a kind keep the status they are written with
+var kindStatuses = map[errs.Kind]int{
+	errs.NotFound:            http.StatusNotFound,
+	errs.InvalidArgument:     http.StatusBadRequest,
+	errs.Conflict:            http.StatusConflict,
+	errs.Unavailable:         http.StatusServiceUnavailable,
+	errs.UpstreamUnavailable: http.StatusBadGateway,
+	errs.Timeout:             http.StatusGatewayTimeout,
+	errs.Canceled:            StatusClientClosedRequest,
+}
+
+// ErrorStatus returns the HTTP status of err by its kind, or fallback when err has no kind
+func ErrorStatus(err error, fallback int) int {
+	if status, ok := kindStatuses[errs.KindOf(err)]; ok {
+		return status
+	}
+	return fallback
+}
+
+// WriteResponse writes data, or the error response when err is set. An error of a known kind
+// is written with the status of its kind, status is used for errors without one.
+// The error response carries the request ID echoed by the request ID middleware.
 func WriteResponse(w http.ResponseWriter, status int, message string, err error, data interface{}) interface{} {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	if err != nil {
+		status = ErrorStatus(err, status)
 		errorResponse := ErrorResponse{
 			Status:       status,
 			Message:      message,
 			Details:      []ErrorDetail{{Field: "general", Message: err.Error()}},
 			LogTimestamp: time.Now().Format(time.RFC3339),
-			RequestID:    uuid.New().String(),
+			RequestID:    w.Header().Get(requestid.Header),
 		}
 
 		w.WriteHeader(status)
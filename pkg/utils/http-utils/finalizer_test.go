@@ -0,0 +1,58 @@
+package httputils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-platform/pkg/requestid"
+	"go-platform/pkg/utils/errs"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "not found", err: errs.New(errs.NotFound, "dog not found"), want: http.StatusNotFound},
+		{name: "invalid argument", err: errs.New(errs.InvalidArgument, "invalid cursor"), want: http.StatusBadRequest},
+		{name: "conflict", err: errs.New(errs.Conflict, "changed"), want: http.StatusConflict},
+		{name: "unavailable", err: errs.New(errs.Unavailable, "queue full"), want: http.StatusServiceUnavailable},
+		{name: "upstream unavailable", err: errs.New(errs.UpstreamUnavailable, "dog API down"), want: http.StatusBadGateway},
+		{name: "timeout", err: fmt.Errorf("call: %w", context.DeadlineExceeded), want: http.StatusGatewayTimeout},
+		{name: "canceled", err: context.Canceled, want: StatusClientClosedRequest},
+		{name: "no kind keeps the fallback", err: errors.New("boom"), want: http.StatusTeapot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorStatus(tt.err, http.StatusTeapot); got != tt.want {
+				t.Fatalf("ErrorStatus() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteResponseError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(requestid.Header, "request-1")
+
+	WriteResponse(rec, http.StatusInternalServerError, "Failed to get dog", errs.New(errs.NotFound, "dog not found"), nil)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	var got ErrorResponse
+	if err := jsoniter.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.Status != http.StatusNotFound || got.RequestID != "request-1" || got.Message != "Failed to get dog" {
+		t.Fatalf("response = %+v, want status %d, request ID %q and the message", got, http.StatusNotFound, "request-1")
+	}
+}
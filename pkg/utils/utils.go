@@ -3,7 +3,9 @@ package utils
 import (
 	"context"
 	"fmt"
+	"go-platform/internal/models/breeds"
 	"go-platform/internal/models/dogs"
+	"go-platform/internal/models/outbox"
 	clickhouseRepo "go-platform/internal/storages/clickhouse"
 	mysqlRepo "go-platform/internal/storages/mysql"
 	"go-platform/internal/storages/postgresql"
@@ -27,7 +29,18 @@ type RepositoryMetricsInterface interface {
 type Repository interface {
 	// return string due to clickhouse dont have auto increment and
 	// we should use uuid for simple row
-	InsertDog(ctx context.Context, dog *dogs.Dog) (string, error)
+	InsertDog(ctx context.Context, dog *dogs.Dog, outboxFn outbox.MessageFunc) (string, error)
+	InsertDogs(ctx context.Context, dogs []*dogs.Dog, outboxFn outbox.BatchMessageFunc) ([]string, error)
+	GetDogByID(ctx context.Context, id string) (*dogs.Dog, error)
+	ListDogs(ctx context.Context, filter dogs.ListDogsFilter) (*dogs.DogsPage, error)
+
+	ReplaceBreeds(ctx context.Context, catalog []breeds.Breed) error
+	ListBreeds(ctx context.Context) ([]breeds.Breed, error)
+
+	ClaimPendingOutbox(ctx context.Context, limit int, lease time.Duration) ([]outbox.Message, error)
+	MarkOutboxPublished(ctx context.Context, ids []string) error
+	MarkOutboxFailed(ctx context.Context, id string, cause error, retryAt time.Time) error
+	MarkOutboxDead(ctx context.Context, id string, cause error) error
 }
 
 // gracefulShutdown handles the graceful shutdown of all services